@@ -0,0 +1,179 @@
+package nmea
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAISFragmentTimeout is the default time the AISAssembler waits for the missing fragments of a message
+	DefaultAISFragmentTimeout = 2 * time.Second
+
+	// maxAISFragments is the maximum number of fragments of a single AIS message
+	maxAISFragments = 9
+)
+
+// AISAssemblerStats contains the statistics of an AISAssembler
+type AISAssemblerStats struct {
+	Assembled uint64 // Number of complete messages
+	Dropped   uint64 // Number of fragments dropped because they were invalid or duplicate
+	Orphaned  uint64 // Number of fragments dropped because the message was not completed in time
+	Pending   uint64 // Number of fragments waiting for the other fragments of their message
+}
+
+// AISAssembler reassembles multi-fragment VDM and VDO sentences into a single VDMVDO. Fragments are
+// grouped on the source of the tag block, the talker, the sentence type, the channel and the
+// sequential message ID, so fragments from different receivers or channels do not interfere.
+// An AISAssembler is safe for concurrent use by multiple goroutines.
+type AISAssembler struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	pending map[aisMessageKey]*aisMessage
+	stats   AISAssemblerStats
+}
+
+type aisMessageKey struct {
+	source       string
+	talker       string
+	typ          string
+	channel      string
+	messageID    int64
+	numFragments int64
+}
+
+type aisMessage struct {
+	started   time.Time
+	fragments []*VDMVDO
+	received  int
+}
+
+// NewAISAssembler constructor, incomplete messages are dropped after the timeout,
+// DefaultAISFragmentTimeout is used when the timeout is 0 or less
+func NewAISAssembler(timeout time.Duration) *AISAssembler {
+	if timeout <= 0 {
+		timeout = DefaultAISFragmentTimeout
+	}
+	return &AISAssembler{
+		timeout: timeout,
+		pending: map[aisMessageKey]*aisMessage{},
+	}
+}
+
+// Add adds a fragment received now, see AddAt
+func (a *AISAssembler) Add(s VDMVDO) (VDMVDO, bool, error) {
+	return a.AddAt(s, time.Now())
+}
+
+// AddAt adds a fragment received at the given time. When the fragment completes a message
+// the assembled VDMVDO is returned together with true, the Fragments of the assembled VDMVDO
+// contain all fragments in order. An error is returned when the fragment is dropped.
+func (a *AISAssembler) AddAt(s VDMVDO, at time.Time) (VDMVDO, bool, error) {
+	return a.addAt(s, at, false)
+}
+
+// addAt adds a fragment, when restart is true a first fragment replaces the incomplete
+// message with the same key instead of being dropped as a duplicate
+func (a *AISAssembler) addAt(s VDMVDO, at time.Time, restart bool) (VDMVDO, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.expire(at)
+
+	numFragments, err := s.NumFragments.GetValue()
+	if err != nil {
+		a.stats.Dropped++
+		return VDMVDO{}, false, fmt.Errorf("nmea: %s invalid number of fragments: %s", s.Prefix(), s.NumFragments.InvalidReason)
+	}
+	if numFragments < 1 || numFragments > maxAISFragments {
+		a.stats.Dropped++
+		return VDMVDO{}, false, fmt.Errorf("nmea: %s invalid number of fragments: %d is not in range (1, %d)", s.Prefix(), numFragments, maxAISFragments)
+	}
+	fragmentNumber, err := s.FragmentNumber.GetValue()
+	if err != nil {
+		a.stats.Dropped++
+		return VDMVDO{}, false, fmt.Errorf("nmea: %s invalid fragment number: %s", s.Prefix(), s.FragmentNumber.InvalidReason)
+	}
+	if fragmentNumber < 1 || fragmentNumber > numFragments {
+		a.stats.Dropped++
+		return VDMVDO{}, false, fmt.Errorf("nmea: %s invalid fragment number: %d is not in range (1, %d)", s.Prefix(), fragmentNumber, numFragments)
+	}
+	if numFragments == 1 {
+		a.stats.Assembled++
+		return assembleVDMVDO([]*VDMVDO{&s}), true, nil
+	}
+
+	key := aisMessageKey{
+		source:       s.TagBlock.Source.Value,
+		talker:       s.Talker,
+		typ:          s.Type,
+		channel:      s.Channel.Value,
+		messageID:    -1,
+		numFragments: numFragments,
+	}
+	if s.MessageID.Valid {
+		key.messageID = s.MessageID.Value
+	}
+	message, ok := a.pending[key]
+	if ok && restart && fragmentNumber == 1 {
+		a.stats.Pending -= uint64(message.received)
+		a.stats.Orphaned += uint64(message.received)
+		ok = false
+	}
+	if !ok {
+		message = &aisMessage{started: at, fragments: make([]*VDMVDO, numFragments)}
+		a.pending[key] = message
+	}
+	if message.fragments[fragmentNumber-1] != nil {
+		a.stats.Dropped++
+		return VDMVDO{}, false, fmt.Errorf("nmea: %s duplicate fragment number: %d", s.Prefix(), fragmentNumber)
+	}
+	message.fragments[fragmentNumber-1] = &s
+	message.received++
+	a.stats.Pending++
+	if message.received < len(message.fragments) {
+		return VDMVDO{}, false, nil
+	}
+
+	delete(a.pending, key)
+	a.stats.Pending -= uint64(message.received)
+	a.stats.Assembled++
+	return assembleVDMVDO(message.fragments), true, nil
+}
+
+// Expire drops the incomplete messages that are older than the timeout at the given time.
+func (a *AISAssembler) Expire(at time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire(at)
+}
+
+// Stats returns the statistics of the assembler
+func (a *AISAssembler) Stats() AISAssemblerStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+func (a *AISAssembler) expire(at time.Time) {
+	for key, message := range a.pending {
+		if at.Sub(message.started) > a.timeout {
+			delete(a.pending, key)
+			a.stats.Pending -= uint64(message.received)
+			a.stats.Orphaned += uint64(message.received)
+		}
+	}
+}
+
+// assembleVDMVDO combines the fragments, the BaseSentence is taken from the first fragment
+func assembleVDMVDO(fragments []*VDMVDO) VDMVDO {
+	m := *fragments[0]
+	m.Payload = nil
+	m.Fragments = make([]BaseSentence, 0, len(fragments))
+	for _, fragment := range fragments {
+		m.Payload = append(m.Payload, fragment.Payload...)
+		m.Fragments = append(m.Fragments, fragment.BaseSentence)
+	}
+	m.Packet = aisCodec.DecodePacket(m.Payload)
+	return m
+}
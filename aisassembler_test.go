@@ -0,0 +1,129 @@
+package nmea_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func withSource(source string, raw string) VDMVDO {
+	tags := "s:" + source
+	sentence, err := Parse(fmt.Sprintf("\\%s*%s\\%s", tags, Checksum(tags), raw))
+	Expect(err).ToNot(HaveOccurred())
+	return sentence.(VDMVDO)
+}
+
+var _ = Describe("AISAssembler", func() {
+	const (
+		fragment1 = "!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11"
+		fragment2 = "!AIVDM,2,2,1,B,Q0C@00000000000,2*44"
+		single    = "!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55"
+	)
+	var (
+		assembler *AISAssembler
+		start     time.Time
+	)
+	BeforeEach(func() {
+		assembler = NewAISAssembler(time.Second)
+		start = time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	})
+	Context("when adding a single fragment message", func() {
+		It("returns the message immediately", func() {
+			result, ok, err := assembler.AddAt(withSource("r1", single), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(result.GetMMSI()).To(Equal("244710402"))
+			Expect(result.Fragments).To(HaveLen(1))
+			Expect(assembler.Stats()).To(Equal(AISAssemblerStats{Assembled: 1}))
+		})
+	})
+	Context("when adding all fragments of a message", func() {
+		It("returns the assembled message with all raw fragments", func() {
+			_, ok, err := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(assembler.Stats().Pending).To(Equal(uint64(1)))
+			result, ok, err := assembler.AddAt(withSource("r1", fragment2), start.Add(100*time.Millisecond))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(result.GetVesselName()).To(Equal("ADELANTE"))
+			Expect(result.Fragments).To(HaveLen(2))
+			Expect(result.Fragments[0].Raw).To(Equal(fragment1))
+			Expect(result.Fragments[1].Raw).To(Equal(fragment2))
+			Expect(assembler.Stats()).To(Equal(AISAssemblerStats{Assembled: 1}))
+		})
+	})
+	Context("when the fragments arrive out of order", func() {
+		It("returns the assembled message", func() {
+			_, ok, _ := assembler.AddAt(withSource("r1", fragment2), start)
+			Expect(ok).To(BeFalse())
+			result, ok, _ := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(ok).To(BeTrue())
+			Expect(result.GetCallSign()).To(Equal("PD8176"))
+		})
+	})
+	Context("when fragments of different sources interleave", func() {
+		It("keeps the messages of the sources apart", func() {
+			_, ok, _ := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(ok).To(BeFalse())
+			_, ok, _ = assembler.AddAt(withSource("r2", fragment1), start)
+			Expect(ok).To(BeFalse())
+			result, ok, _ := assembler.AddAt(withSource("r2", fragment2), start)
+			Expect(ok).To(BeTrue())
+			Expect(result.TagBlock.Source).To(Equal(NewString("r2")))
+			Expect(assembler.Stats().Pending).To(Equal(uint64(1)))
+		})
+	})
+	Context("when a fragment is received twice", func() {
+		It("drops the duplicate", func() {
+			_, _, err := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(err).ToNot(HaveOccurred())
+			_, ok, err := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError("nmea: AIVDM duplicate fragment number: 1"))
+			Expect(assembler.Stats().Dropped).To(Equal(uint64(1)))
+		})
+	})
+	Context("when a fragment has an invalid fragment number", func() {
+		It("drops the fragment", func() {
+			_, ok, err := assembler.AddAt(withSource("r1", "!AIVDM,2,3,1,B,Q0C@00000000000,2*45"), start)
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError("nmea: AIVDM invalid fragment number: 3 is not in range (1, 2)"))
+			Expect(assembler.Stats().Dropped).To(Equal(uint64(1)))
+		})
+	})
+	Context("when the remaining fragments arrive after the timeout", func() {
+		It("orphans the incomplete message", func() {
+			_, ok, _ := assembler.AddAt(withSource("r1", fragment1), start)
+			Expect(ok).To(BeFalse())
+			_, ok, _ = assembler.AddAt(withSource("r1", fragment2), start.Add(2*time.Second))
+			Expect(ok).To(BeFalse())
+			Expect(assembler.Stats()).To(Equal(AISAssemblerStats{Orphaned: 1, Pending: 1}))
+			assembler.Expire(start.Add(4 * time.Second))
+			Expect(assembler.Stats()).To(Equal(AISAssemblerStats{Orphaned: 2}))
+		})
+	})
+	Context("when used from multiple goroutines", func() {
+		It("assembles all messages", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(source string) {
+					defer wg.Done()
+					defer GinkgoRecover()
+					_, _, err := assembler.Add(withSource(source, fragment1))
+					Expect(err).ToNot(HaveOccurred())
+					_, ok, err := assembler.Add(withSource(source, fragment2))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ok).To(BeTrue())
+				}(fmt.Sprintf("r%d", i))
+			}
+			wg.Wait()
+			Expect(assembler.Stats()).To(Equal(AISAssemblerStats{Assembled: 20}))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package nmea
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	// TypeALR type for ALR sentences
@@ -61,3 +64,22 @@ func (s ALR) GetDescription() (string, error) {
 	}
 	return s.Description.Value, nil
 }
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s ALR) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the ALR sentence into NMEA 0183 text
+func (s ALR) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.StringField(s.Identifier)
+	e.StringField(s.Condition)
+	e.StringField(s.State)
+	e.StringField(s.Description)
+	return e.Encode()
+}
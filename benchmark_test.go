@@ -0,0 +1,38 @@
+package nmea_test
+
+import (
+	"testing"
+
+	. "github.com/munnik/go-nmea"
+)
+
+var benchmarkSentences = []struct {
+	name string
+	raw  string
+}{
+	{"RMC", "$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03"},
+	{"GGA", "$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,72.5,M,41.5,M,,*7C"},
+	{"HDT", "$GPHDT,123.456,T*32"},
+	{"VDM", "!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55"},
+	{"VDM with tag block", "\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52"},
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, bm := range benchmarkSentences {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(bm.raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkChecksum(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Checksum("GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A")
+	}
+}
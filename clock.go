@@ -0,0 +1,106 @@
+package nmea
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// dayDuration is the duration of a day in UTC
+	dayDuration = 24 * time.Hour
+)
+
+// ErrNoDate is returned by the Clock when the time of a sentence can not be combined with a date
+var ErrNoDate = errors.New("nmea: no date for the time of day")
+
+// Clock reconstructs the date and time of sentences that only contain the time of day, such as
+// GGA, GLL, GNS and GST. The time of day is combined with the most recent date of a RMC or ZDA
+// sentence or the time of a tag block. The date is advanced when the time of day passes midnight
+// and the previous date is used for a sentence from just before midnight that is received after
+// a sentence from the new day. The times are returned in the local time zone of the most recent
+// ZDA sentence, or in UTC when there is none. A Clock is safe for concurrent use by multiple
+// goroutines.
+type Clock struct {
+	mu        sync.Mutex
+	reference time.Time
+	location  *time.Location
+}
+
+// NewClock constructor
+func NewClock() *Clock {
+	return &Clock{location: time.UTC}
+}
+
+// Time returns the date and time of the sentence and updates the clock with it. The date and time
+// of a sentence with a date, e.g. RMC and ZDA, is returned as is. The time of day of other
+// sentences is combined with the date of the tag block, or the date of the clock when the sentence
+// has no tag block time. A sentence without a time of day gets the time of its tag block.
+// ErrNoDate is returned when the time can not be reconstructed.
+func (c *Clock) Time(s Sentence) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := s.(ZDA); ok {
+		if location, err := v.GetLocation(); err == nil {
+			c.location = location
+		}
+	}
+	if v, ok := s.(Timestamp); ok {
+		if t, err := v.GetTimestamp(); err == nil {
+			c.reference = t
+			return t.In(c.location), nil
+		}
+	}
+
+	reference := c.reference
+	tagBlockTime := false
+	if t, ok := s.(tagBlocker); ok && t.tagBlock().Valid && t.tagBlock().Time.Valid {
+		reference = t.tagBlock().TimeValue()
+		tagBlockTime = true
+	}
+	if v, ok := s.(TimeOfDay); ok {
+		if timeOfDay, err := v.GetTimeOfDay(); err == nil && !reference.IsZero() {
+			c.reference = combineTimeOfDay(reference, timeOfDay)
+			return c.reference.In(c.location), nil
+		}
+	}
+	if tagBlockTime {
+		c.reference = reference
+		return reference.In(c.location), nil
+	}
+	return time.Time{}, ErrNoDate
+}
+
+// Reference returns the most recent date and time of the clock, the zero time.Time is returned
+// when the clock has no date yet
+func (c *Clock) Reference() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.reference.IsZero() {
+		return c.reference
+	}
+	return c.reference.In(c.location)
+}
+
+// Location returns the local time zone of the most recent ZDA sentence, UTC when there is none
+func (c *Clock) Location() *time.Location {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.location
+}
+
+// combineTimeOfDay returns the time of day on the day of the reference that is closest to the
+// reference, so it is on the next day when midnight passed since the reference and on the
+// previous day when the time of day is from just before midnight of the day of the reference
+func combineTimeOfDay(reference time.Time, timeOfDay time.Duration) time.Time {
+	reference = reference.UTC()
+	t := reference.Truncate(dayDuration).Add(timeOfDay)
+	switch {
+	case t.Sub(reference) < -dayDuration/2:
+		t = t.Add(dayDuration)
+	case t.Sub(reference) > dayDuration/2:
+		t = t.Add(-dayDuration)
+	}
+	return t
+}
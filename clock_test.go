@@ -0,0 +1,93 @@
+package nmea_test
+
+import (
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Clock", func() {
+	var clock *Clock
+	rmc := func(year, month, day int, t Time) RMC {
+		return RMC{
+			BaseSentence: BaseSentence{Talker: "GP", Type: "RMC"},
+			Time:         t,
+			Validity:     NewString(ValidRMC),
+			Date:         NewDate(year, month, day),
+		}
+	}
+	gga := func(t Time) GGA {
+		return GGA{BaseSentence: BaseSentence{Talker: "GP", Type: "GGA"}, Time: t}
+	}
+	BeforeEach(func() {
+		clock = NewClock()
+	})
+	Context("when there is no date", func() {
+		It("returns an error for a sentence with a time of day", func() {
+			_, err := clock.Time(gga(NewTime(12, 0, 0, 0)))
+			Expect(err).To(MatchError(ErrNoDate))
+			Expect(clock.Reference().IsZero()).To(BeTrue())
+		})
+	})
+	Context("when there is a date", func() {
+		JustBeforeEach(func() {
+			Expect(clock.Time(rmc(21, 4, 23, NewTime(11, 45, 9, 300)))).To(Equal(time.Date(2021, 4, 23, 11, 45, 9, 300000000, time.UTC)))
+		})
+		It("combines the time of day with the date", func() {
+			Expect(clock.Time(gga(NewTime(11, 45, 10, 0)))).To(Equal(time.Date(2021, 4, 23, 11, 45, 10, 0, time.UTC)))
+			Expect(clock.Reference()).To(Equal(time.Date(2021, 4, 23, 11, 45, 10, 0, time.UTC)))
+		})
+		It("returns an error for a sentence without a time of day", func() {
+			_, err := clock.Time(HDT{})
+			Expect(err).To(MatchError(ErrNoDate))
+		})
+	})
+	Context("when the time of day passes midnight", func() {
+		It("advances the date", func() {
+			Expect(clock.Time(rmc(21, 12, 31, NewTime(23, 59, 59, 0)))).To(Equal(time.Date(2021, 12, 31, 23, 59, 59, 0, time.UTC)))
+			Expect(clock.Time(gga(NewTime(0, 0, 1, 0)))).To(Equal(time.Date(2022, 1, 1, 0, 0, 1, 0, time.UTC)))
+			Expect(clock.Time(gga(NewTime(0, 0, 2, 0)))).To(Equal(time.Date(2022, 1, 1, 0, 0, 2, 0, time.UTC)))
+		})
+		It("uses the previous date for a time of day from before midnight", func() {
+			Expect(clock.Time(rmc(22, 1, 1, NewTime(0, 0, 1, 0)))).To(Equal(time.Date(2022, 1, 1, 0, 0, 1, 0, time.UTC)))
+			Expect(clock.Time(gga(NewTime(23, 59, 59, 0)))).To(Equal(time.Date(2021, 12, 31, 23, 59, 59, 0, time.UTC)))
+		})
+	})
+	Context("when the sentence has a tag block time", func() {
+		It("combines the time of day with the date of the tag block", func() {
+			s := gga(NewTime(1, 22, 20, 0))
+			s.TagBlock = TagBlock{Valid: true, Time: NewInt64(1553390539)}
+			Expect(clock.Time(s)).To(Equal(time.Date(2019, 3, 24, 1, 22, 20, 0, time.UTC)))
+		})
+		It("returns the time of the tag block for a sentence without a time of day", func() {
+			s, err := Parse("\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clock.Time(s)).To(Equal(time.Date(2019, 3, 24, 1, 22, 19, 0, time.UTC)))
+			Expect(clock.Reference()).To(Equal(time.Date(2019, 3, 24, 1, 22, 19, 0, time.UTC)))
+		})
+	})
+	Context("when a ZDA sentence has a local time zone", func() {
+		It("returns the times in the local time zone", func() {
+			zda := ZDA{
+				BaseSentence:  BaseSentence{Talker: "GP", Type: "ZDA"},
+				Time:          NewTime(20, 5, 45, 0),
+				Day:           NewInt64(16),
+				Month:         NewInt64(4),
+				Year:          NewInt64(2021),
+				OffsetHours:   NewInt64(-2),
+				OffsetMinutes: NewInt64(0),
+			}
+			t, err := clock.Time(zda)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Hour()).To(Equal(22))
+			t, err = clock.Time(gga(NewTime(20, 5, 46, 0)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Equal(time.Date(2021, 4, 16, 20, 5, 46, 0, time.UTC))).To(BeTrue())
+			Expect(t.Hour()).To(Equal(22))
+			_, offset := t.Zone()
+			Expect(offset).To(Equal(2 * 3600))
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package nmea
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	// DefaultPivotYear is the default first year of two digit years, the two digit years 80 to 99
+	// are 1980 to 1999 and 00 to 79 are 2000 to 2079. NMEA 0183 was introduced in 1983.
+	DefaultPivotYear = 1980
+	// GPSWeekRollover is the period after which the 10 bit GPS week number rolls over (1024 weeks)
+	GPSWeekRollover = 1024 * 7 * 24 * time.Hour
+)
+
+// lastGPSWeekRollover is the start of the GPS week after the most recent rollover, it is the
+// default rollover reference
+var lastGPSWeekRollover = time.Date(2019, 4, 7, 0, 0, 0, 0, time.UTC)
+
+// buildDate is the commit time of the build, the zero time.Time when it is unknown
+var buildDate = readBuildDate()
+
+// DateConfig contains the options of the interpretation of dates, the zero value uses the
+// default pivot year and does not correct the GPS week rollover
+type DateConfig struct {
+	// PivotYear is the first year of two digit years, a two digit year is in the range of 100
+	// years starting at the pivot year. DefaultPivotYear is used when it is 0 or less.
+	PivotYear int
+	// CorrectWeekRollover advances dates before the rollover reference by multiples of 1024
+	// weeks, e.g. a receiver that is not updated for the 2019 rollover reports 2006 in 2026
+	CorrectWeekRollover bool
+	// RolloverReference is the earliest date that can be reported by the receivers, the last
+	// rollover (2019-04-07) is used when it is zero. Dates of logs recorded before the reference
+	// are advanced as well, so it must not be later than the oldest log that is parsed.
+	RolloverReference time.Time
+	// UseBuildDate uses the commit time of the build of the program as the rollover reference
+	// when RolloverReference is zero, the last rollover is used when the build has no version
+	// control information. It is only suitable for live data.
+	UseBuildDate bool
+}
+
+// Year returns the year of a Date, a two digit year is interpreted with the pivot year and other
+// years are returned as is
+func (c DateConfig) Year(d Date) int {
+	if d.YY >= 100 {
+		return d.YY
+	}
+	pivot := c.PivotYear
+	if pivot <= 0 {
+		pivot = DefaultPivotYear
+	}
+	year := pivot - pivot%100 + d.YY
+	if year < pivot {
+		year += 100
+	}
+	return year
+}
+
+// Time combines the Date with the Time into a time.Time in UTC and corrects the GPS week
+// rollover when it is enabled, an error is returned when the Date or the Time is invalid
+func (c DateConfig) Time(d Date, t Time) (time.Time, error) {
+	if !d.Valid || !t.Valid {
+		return time.Time{}, fmt.Errorf("value is unavailable")
+	}
+	return c.Correct(time.Date(c.Year(d), time.Month(d.MM), d.DD, 0, 0, 0, 0, time.UTC).Add(t.Duration())), nil
+}
+
+// Correct advances a time before the rollover reference by multiples of 1024 weeks until it is
+// not before the rollover reference, the time is returned as is when the correction is disabled
+func (c DateConfig) Correct(t time.Time) time.Time {
+	if !c.CorrectWeekRollover {
+		return t
+	}
+	reference := c.RolloverReference
+	if reference.IsZero() && c.UseBuildDate {
+		reference = buildDate
+	}
+	if reference.IsZero() {
+		reference = lastGPSWeekRollover
+	}
+	for t.Before(reference) {
+		t = t.Add(GPSWeekRollover)
+	}
+	return t
+}
+
+// readBuildDate returns the commit time of the build info, the zero time.Time when the program
+// is built without version control information
+func readBuildDate() time.Time {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return time.Time{}
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.time" {
+			if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,93 @@
+package nmea_test
+
+import (
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DateConfig", func() {
+	reference := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	Context("when getting the year of a date", func() {
+		It("uses the default pivot year", func() {
+			Expect(DateConfig{}.Year(NewDate(80, 1, 1))).To(Equal(1980))
+			Expect(DateConfig{}.Year(NewDate(79, 1, 1))).To(Equal(2079))
+		})
+		It("uses the configured pivot year", func() {
+			config := DateConfig{PivotYear: 1995}
+			Expect(config.Year(NewDate(95, 1, 1))).To(Equal(1995))
+			Expect(config.Year(NewDate(94, 1, 1))).To(Equal(2094))
+			Expect(config.Year(NewDate(21, 1, 1))).To(Equal(2021))
+		})
+		It("returns a four digit year as is", func() {
+			Expect(DateConfig{PivotYear: 2000}.Year(NewDate(1996, 1, 1))).To(Equal(1996))
+		})
+	})
+	Context("when correcting the GPS week rollover", func() {
+		It("returns the time as is when the correction is disabled", func() {
+			t := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(DateConfig{RolloverReference: reference}.Correct(t)).To(Equal(t))
+		})
+		It("returns a time after the reference as is", func() {
+			t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true, RolloverReference: reference}.Correct(t)).To(Equal(t))
+		})
+		It("advances a time before the reference by 1024 weeks", func() {
+			t := time.Date(2001, 1, 1, 12, 0, 0, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true, RolloverReference: reference}.Correct(t)).To(Equal(time.Date(2020, 8, 17, 12, 0, 0, 0, time.UTC)))
+		})
+		It("advances a time by multiple rollovers", func() {
+			t := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+			config := DateConfig{CorrectWeekRollover: true, RolloverReference: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+			Expect(config.Correct(t)).To(Equal(time.Date(2040, 4, 2, 0, 0, 0, 0, time.UTC)))
+		})
+		It("uses the last rollover without a reference", func() {
+			t := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true}.Correct(t)).To(Equal(time.Date(2020, 8, 17, 0, 0, 0, 0, time.UTC)))
+		})
+		It("does not advance a time of an archived log after the last rollover", func() {
+			t := time.Date(2021, 4, 23, 11, 45, 9, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true}.Correct(t)).To(Equal(t))
+		})
+		It("uses the build date or the last rollover when the build date is enabled", func() {
+			t := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true, UseBuildDate: true}.Correct(t).After(time.Date(2019, 4, 6, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+		It("prefers the reference over the build date", func() {
+			t := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			Expect(DateConfig{CorrectWeekRollover: true, UseBuildDate: true, RolloverReference: reference}.Correct(t)).To(Equal(t))
+		})
+	})
+	Context("when combining a date with a time", func() {
+		It("returns an error when the date is invalid", func() {
+			_, err := DateConfig{}.Time(NewInvalidDate(""), NewTime(1, 2, 3, 0))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("when parsing sentences with a date config", func() {
+		config := ParseConfig{Date: DateConfig{CorrectWeekRollover: true, RolloverReference: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}}
+		It("corrects the date and time of a RMC sentence", func() {
+			s, err := ParseWithConfig("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C", config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(RMC).GetTimestamp()).To(Equal(time.Date(2040, 12, 7, 11, 45, 9, 300000000, time.UTC)))
+			Expect(s.(RMC).GetDateTime()).To(Equal("2040-12-07T11:45:09.3Z"))
+		})
+		It("corrects the date and time of a ZDA sentence", func() {
+			s, err := ParseWithConfig("$GPZDA,172809.456,12,07,1996,00,00*57", ParseConfig{Date: DateConfig{CorrectWeekRollover: true, RolloverReference: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(ZDA).GetTimestamp()).To(Equal(time.Date(2016, 2, 26, 17, 28, 9, 456000000, time.UTC)))
+		})
+		It("interprets a two digit year with the pivot year", func() {
+			s, err := ParseWithConfig("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C", ParseConfig{Date: DateConfig{PivotYear: 1900}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(RMC).GetDateTime()).To(Equal("1921-04-23T11:45:09.3Z"))
+		})
+		It("does not change the date without a date config", func() {
+			s, err := Parse("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(RMC).GetDateTime()).To(Equal("2021-04-23T11:45:09.3Z"))
+		})
+	})
+})
@@ -1,10 +1,6 @@
 package nmea
 
-import (
-	"fmt"
-
-	"github.com/martinlindhe/unit"
-)
+import "fmt"
 
 const (
 	// TypeDBS type for DBS sentences
@@ -15,9 +11,9 @@ const (
 // https://gpsd.gitlab.io/gpsd/NMEA.html#_dbs_depth_below_surface
 type DBS struct {
 	BaseSentence
-	DepthFeet    Float64
-	DepthMeters  Float64
-	DepthFathoms Float64
+	DepthFeet    Length
+	DepthMeters  Length
+	DepthFathoms Length
 }
 
 // newDBS constructor
@@ -26,22 +22,30 @@ func newDBS(s BaseSentence) (DBS, error) {
 	p.AssertType(TypeDBS)
 	return DBS{
 		BaseSentence: s,
-		DepthFeet:    p.Float64(0, "depth_feet"),
-		DepthMeters:  p.Float64(2, "depth_meters"),
-		DepthFathoms: p.Float64(4, "depth_fathoms"),
+		DepthFeet:    p.Length(0, 1, "depth_feet", LengthUnitFeet),
+		DepthMeters:  p.Length(2, 3, "depth_meters", LengthUnitMeters),
+		DepthFathoms: p.Length(4, 5, "depth_fathoms", LengthUnitFathoms),
 	}, p.Err()
 }
 
 // GetDepthBelowSurface retrieves the depth below surface from the sentence
 func (s DBS) GetDepthBelowSurface() (float64, error) {
-	if v, err := s.DepthMeters.GetValue(); err == nil {
-		return v, nil
-	}
-	if v, err := s.DepthFeet.GetValue(); err == nil {
-		return (unit.Length(v) * unit.Foot).Meters(), nil
-	}
-	if v, err := s.DepthFathoms.GetValue(); err == nil {
-		return (unit.Length(v) * unit.Fathom).Meters(), nil
+	for _, depth := range []Length{s.DepthMeters, s.DepthFeet, s.DepthFathoms} {
+		if v, err := depth.GetLength(); err == nil {
+			return v.Meters(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the DBS sentence into NMEA 0183 text
+func (s DBS) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.DepthFeet.Float64)
+	e.RawField(LengthUnitFeet)
+	e.Float64(s.DepthMeters.Float64)
+	e.RawField(LengthUnitMeters)
+	e.Float64(s.DepthFathoms.Float64)
+	e.RawField(LengthUnitFathoms)
+	return e.Encode()
+}
@@ -32,9 +32,9 @@ var _ = Describe("DBS", func() {
 			})
 			It("equals a valid DBS struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"DepthFeet":    Equal(NewFloat64(1.9)),
-					"DepthMeters":  Equal(NewFloat64(0.58)),
-					"DepthFathoms": Equal(NewFloat64(0.3)),
+					"DepthFeet":    Equal(NewLength(1.9, LengthUnitFeet)),
+					"DepthMeters":  Equal(NewLength(0.58, LengthUnitMeters)),
+					"DepthFathoms": Equal(NewLength(0.3, LengthUnitFathoms)),
 				}))
 			})
 		})
@@ -53,9 +53,9 @@ var _ = Describe("DBS", func() {
 	Describe("Getting data from a DBS struct", func() {
 		BeforeEach(func() {
 			parsed = DBS{
-				DepthFeet:    NewFloat64(DepthBelowSurfaceFeet),
-				DepthMeters:  NewFloat64(DepthBelowSurfaceMeters),
-				DepthFathoms: NewFloat64(DepthBelowSurfaceFathoms),
+				DepthFeet:    NewLength(DepthBelowSurfaceFeet, LengthUnitFeet),
+				DepthMeters:  NewLength(DepthBelowSurfaceMeters, LengthUnitMeters),
+				DepthFathoms: NewLength(DepthBelowSurfaceFathoms, LengthUnitFathoms),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -65,8 +65,8 @@ var _ = Describe("DBS", func() {
 		})
 		Context("when having a struct with only depth in feet set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthMeters = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthMeters = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowSurface()).To(BeNumerically("~", DepthBelowSurfaceMeters, 0.00001))
@@ -74,8 +74,8 @@ var _ = Describe("DBS", func() {
 		})
 		Context("when having a struct with only depth in fathoms set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthMeters = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthMeters = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowSurface()).To(BeNumerically("~", DepthBelowSurfaceMeters, 0.00001))
@@ -83,8 +83,8 @@ var _ = Describe("DBS", func() {
 		})
 		Context("when having a struct with only depth in meters set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowSurface()).To(BeNumerically("~", DepthBelowSurfaceMeters, 0.00001))
@@ -92,9 +92,9 @@ var _ = Describe("DBS", func() {
 		})
 		Context("when having a struct with missing depth values", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthMeters = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthMeters = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetDepthBelowSurface()
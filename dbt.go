@@ -1,10 +1,6 @@
 package nmea
 
-import (
-	"fmt"
-
-	"github.com/martinlindhe/unit"
-)
+import "fmt"
 
 const (
 	// TypeDBT type for DBT sentences
@@ -15,9 +11,9 @@ const (
 // https://gpsd.gitlab.io/gpsd/NMEA.html#_dbt_depth_below_transducer
 type DBT struct {
 	BaseSentence
-	DepthFeet    Float64
-	DepthMeters  Float64
-	DepthFathoms Float64
+	DepthFeet    Length
+	DepthMeters  Length
+	DepthFathoms Length
 }
 
 // newDBT constructor
@@ -26,22 +22,30 @@ func newDBT(s BaseSentence) (DBT, error) {
 	p.AssertType(TypeDBT)
 	return DBT{
 		BaseSentence: s,
-		DepthFeet:    p.Float64(0, "depth_feet"),
-		DepthMeters:  p.Float64(2, "depth_meters"),
-		DepthFathoms: p.Float64(4, "depth_fathoms"),
+		DepthFeet:    p.Length(0, 1, "depth_feet", LengthUnitFeet),
+		DepthMeters:  p.Length(2, 3, "depth_meters", LengthUnitMeters),
+		DepthFathoms: p.Length(4, 5, "depth_fathoms", LengthUnitFathoms),
 	}, p.Err()
 }
 
 // GetDepthBelowTransducer retrieves the depth below the transducer from the sentence
 func (s DBT) GetDepthBelowTransducer() (float64, error) {
-	if v, err := s.DepthMeters.GetValue(); err == nil {
-		return v, nil
-	}
-	if v, err := s.DepthFeet.GetValue(); err == nil {
-		return (unit.Length(v) * unit.Foot).Meters(), nil
-	}
-	if v, err := s.DepthFathoms.GetValue(); err == nil {
-		return (unit.Length(v) * unit.Fathom).Meters(), nil
+	for _, depth := range []Length{s.DepthMeters, s.DepthFeet, s.DepthFathoms} {
+		if v, err := depth.GetLength(); err == nil {
+			return v.Meters(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the DBT sentence into NMEA 0183 text
+func (s DBT) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.DepthFeet.Float64)
+	e.RawField(LengthUnitFeet)
+	e.Float64(s.DepthMeters.Float64)
+	e.RawField(LengthUnitMeters)
+	e.Float64(s.DepthFathoms.Float64)
+	e.RawField(LengthUnitFathoms)
+	return e.Encode()
+}
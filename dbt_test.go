@@ -32,9 +32,9 @@ var _ = Describe("DBT", func() {
 			})
 			It("equals a valid DBT struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"DepthFeet":    Equal(NewFloat64(32.93)),
-					"DepthMeters":  Equal(NewFloat64(10.04)),
-					"DepthFathoms": Equal(NewFloat64(5.42)),
+					"DepthFeet":    Equal(NewLength(32.93, LengthUnitFeet)),
+					"DepthMeters":  Equal(NewLength(10.04, LengthUnitMeters)),
+					"DepthFathoms": Equal(NewLength(5.42, LengthUnitFathoms)),
 				}))
 			})
 		})
@@ -53,9 +53,9 @@ var _ = Describe("DBT", func() {
 	Describe("Getting data from a DBT struct", func() {
 		BeforeEach(func() {
 			parsed = DBT{
-				DepthFeet:    NewFloat64(DepthBelowSurfaceFeet - DepthTransducerFeet),
-				DepthMeters:  NewFloat64(DepthBelowSurfaceMeters - DepthTransducerMeters),
-				DepthFathoms: NewFloat64(DepthBelowSurfaceFathoms - DepthTransducerFathoms),
+				DepthFeet:    NewLength(DepthBelowSurfaceFeet-DepthTransducerFeet, LengthUnitFeet),
+				DepthMeters:  NewLength(DepthBelowSurfaceMeters-DepthTransducerMeters, LengthUnitMeters),
+				DepthFathoms: NewLength(DepthBelowSurfaceFathoms-DepthTransducerFathoms, LengthUnitFathoms),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -65,8 +65,8 @@ var _ = Describe("DBT", func() {
 		})
 		Context("when having a struct with only depth in feet set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthMeters = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthMeters = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -74,8 +74,8 @@ var _ = Describe("DBT", func() {
 		})
 		Context("when having a struct with only depth in fathoms set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthMeters = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthMeters = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -83,8 +83,8 @@ var _ = Describe("DBT", func() {
 		})
 		Context("when having a struct with only depth in meters set", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns a valid depth below surface", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -92,9 +92,9 @@ var _ = Describe("DBT", func() {
 		})
 		Context("when having a struct with missing depth values", func() {
 			JustBeforeEach(func() {
-				parsed.DepthFeet = NewInvalidFloat64("")
-				parsed.DepthMeters = NewInvalidFloat64("")
-				parsed.DepthFathoms = NewInvalidFloat64("")
+				parsed.DepthFeet = NewInvalidLength("")
+				parsed.DepthMeters = NewInvalidLength("")
+				parsed.DepthFathoms = NewInvalidLength("")
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetDepthBelowTransducer()
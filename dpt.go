@@ -11,9 +11,9 @@ const (
 // https://gpsd.gitlab.io/gpsd/NMEA.html#_dpt_depth_of_water
 type DPT struct {
 	BaseSentence
-	Depth      Float64
-	Offset     Float64
-	RangeScale Float64
+	Depth      Length
+	Offset     Length
+	RangeScale Length
 }
 
 // newDPT constructor
@@ -22,25 +22,25 @@ func newDPT(s BaseSentence) (DPT, error) {
 	p.AssertType(TypeDPT)
 	return DPT{
 		BaseSentence: s,
-		Depth:        p.Float64(0, "depth"),
-		Offset:       p.Float64(1, "offset"),
-		RangeScale:   p.Float64(2, "range scale"),
+		Depth:        p.Length(0, -1, "depth", LengthUnitMeters),
+		Offset:       p.Length(1, -1, "offset", LengthUnitMeters),
+		RangeScale:   p.Length(2, -1, "range scale", LengthUnitMeters),
 	}, p.Err()
 }
 
 // GetDepthBelowTransducer retrieves the depth below the keel from the sentence
 func (s DPT) GetDepthBelowTransducer() (float64, error) {
-	if v, err := s.Depth.GetValue(); err == nil {
-		return v, nil
+	if v, err := s.Depth.GetLength(); err == nil {
+		return v.Meters(), nil
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetDepthBelowKeel retrieves the depth below the keel from the sentence
 func (s DPT) GetDepthBelowKeel() (float64, error) {
-	if vDepth, err := s.Depth.GetValue(); err == nil {
-		if vOffset, err := s.Offset.GetValue(); err == nil && vOffset < 0 {
-			return vDepth + vOffset, nil
+	if vDepth, err := s.Depth.GetLength(); err == nil {
+		if vOffset, err := s.Offset.GetLength(); err == nil && vOffset < 0 {
+			return (vDepth + vOffset).Meters(), nil
 		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
@@ -48,10 +48,19 @@ func (s DPT) GetDepthBelowKeel() (float64, error) {
 
 // GetDepthBelowSurface retrieves the depth below surface from the sentence
 func (s DPT) GetDepthBelowSurface() (float64, error) {
-	if vDepth, err := s.Depth.GetValue(); err == nil {
-		if vOffset, err := s.Offset.GetValue(); err == nil && vOffset > 0 {
-			return vDepth + vOffset, nil
+	if vDepth, err := s.Depth.GetLength(); err == nil {
+		if vOffset, err := s.Offset.GetLength(); err == nil && vOffset > 0 {
+			return (vDepth + vOffset).Meters(), nil
 		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the DPT sentence into NMEA 0183 text
+func (s DPT) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Depth.Float64)
+	e.Float64(s.Offset.Float64)
+	e.Float64(s.RangeScale.Float64)
+	return e.Encode()
+}
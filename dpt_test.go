@@ -32,9 +32,9 @@ var _ = Describe("DPT", func() {
 			})
 			It("equals a valid DPT struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Depth":      Equal(NewFloat64(0.5)),
-					"Offset":     Equal(NewFloat64(0.5)),
-					"RangeScale": Equal(NewInvalidFloat64("strconv.ParseFloat: parsing \"\": invalid syntax")),
+					"Depth":      Equal(NewLength(0.5, LengthUnitMeters)),
+					"Offset":     Equal(NewLength(0.5, LengthUnitMeters)),
+					"RangeScale": Equal(Length{Float64: NewInvalidFloat64("strconv.ParseFloat: parsing \"\": invalid syntax"), Unit: LengthUnitMeters}),
 				}))
 			})
 		})
@@ -47,9 +47,9 @@ var _ = Describe("DPT", func() {
 			})
 			It("equals a valid DPT struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Depth":      Equal(NewFloat64(0.5)),
-					"Offset":     Equal(NewFloat64(0.5)),
-					"RangeScale": Equal(NewFloat64(0.1)),
+					"Depth":      Equal(NewLength(0.5, LengthUnitMeters)),
+					"Offset":     Equal(NewLength(0.5, LengthUnitMeters)),
+					"RangeScale": Equal(NewLength(0.1, LengthUnitMeters)),
 				}))
 			})
 		})
@@ -65,12 +65,12 @@ var _ = Describe("DPT", func() {
 	Describe("Getting data from a DPT struct", func() {
 		BeforeEach(func() {
 			parsed = DPT{
-				Depth: NewFloat64(DepthBelowSurfaceMeters - DepthTransducerMeters),
+				Depth: NewLength(DepthBelowSurfaceMeters-DepthTransducerMeters, LengthUnitMeters),
 			}
 		})
 		Context("When having a parsed sentence and a positive offset", func() {
 			JustBeforeEach(func() {
-				parsed.Offset = NewFloat64(DepthTransducerMeters)
+				parsed.Offset = NewLength(DepthTransducerMeters, LengthUnitMeters)
 			})
 			It("returns a valid depth below transducer", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -85,7 +85,7 @@ var _ = Describe("DPT", func() {
 		})
 		Context("When having a parsed sentence and a negative offset", func() {
 			JustBeforeEach(func() {
-				parsed.Offset = NewFloat64(DepthTransducerMeters - DepthKeelMeters)
+				parsed.Offset = NewLength(DepthTransducerMeters-DepthKeelMeters, LengthUnitMeters)
 			})
 			It("returns a valid depth below transducer", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -100,7 +100,7 @@ var _ = Describe("DPT", func() {
 		})
 		Context("When having a parsed sentence and no offset", func() {
 			JustBeforeEach(func() {
-				parsed.Offset = NewInvalidFloat64("")
+				parsed.Offset = NewInvalidLength("")
 			})
 			It("returns a valid depth below transducer", func() {
 				Expect(parsed.GetDepthBelowTransducer()).To(BeNumerically("~", DepthBelowSurfaceMeters-DepthTransducerMeters, 0.00001))
@@ -116,8 +116,8 @@ var _ = Describe("DPT", func() {
 		})
 		Context("When having a parsed sentence and no depth", func() {
 			JustBeforeEach(func() {
-				parsed.Depth = NewInvalidFloat64("")
-				parsed.Offset = NewFloat64(DepthTransducerMeters)
+				parsed.Depth = NewInvalidLength("")
+				parsed.Offset = NewLength(DepthTransducerMeters, LengthUnitMeters)
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetDepthBelowTransducer()
@@ -0,0 +1,280 @@
+package nmea
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Encodable is implemented by sentences that can be serialized back into NMEA 0183 text
+type Encodable interface {
+	Sentence
+	Encode() (string, error)
+}
+
+// Encode serializes the given sentence back into NMEA 0183 text.
+func Encode(s Sentence) (string, error) {
+	if e, ok := s.(Encodable); ok {
+		return e.Encode()
+	}
+	return "", fmt.Errorf("nmea: sentence prefix '%s' can not be encoded", s.Prefix())
+}
+
+// Encoder provides a simple way of building the fields of a sentence,
+// it is the counterpart of the Parser. A field that has the same value as the field at the same
+// index of the given sentence is added as is, so unchanged fields keep their original format,
+// e.g. leading and trailing zeros.
+type Encoder struct {
+	sentence BaseSentence // The sentence that is encoded
+	start    string
+	fields   []string
+	err      error
+}
+
+// NewEncoder constructor, the talker, type and tag block are taken from the given sentence
+func NewEncoder(s BaseSentence) *Encoder {
+	start := SentenceStart
+	if strings.HasPrefix(s.Raw, SentenceStartEncapsulated) {
+		start = SentenceStartEncapsulated
+	}
+	return &Encoder{sentence: s, start: start}
+}
+
+// Encapsulated makes the encoder use the '!' start delimiter.
+func (e *Encoder) Encapsulated() {
+	e.start = SentenceStartEncapsulated
+}
+
+// Err returns the first error encountered during the encoder's usage.
+func (e *Encoder) Err() error {
+	return e.err
+}
+
+// SetErr assigns an error. Calling this method has no
+// effect if there is already an error.
+func (e *Encoder) SetErr(context, value string) {
+	if e.err == nil {
+		e.err = fmt.Errorf("nmea: %s can not encode %s: %s", e.sentence.Prefix(), context, value)
+	}
+}
+
+// RawField adds the values as is.
+func (e *Encoder) RawField(values ...string) {
+	e.fields = append(e.fields, values...)
+}
+
+// StringField adds the value of the String, an invalid String results in an empty field.
+func (e *Encoder) StringField(v String) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	e.RawField(v.Value)
+}
+
+// ListString adds a field for each of the values in the list.
+func (e *Encoder) ListString(v StringList) {
+	if !v.Valid {
+		return
+	}
+	for _, s := range v.Values {
+		e.StringField(s)
+	}
+}
+
+// EnumChars adds the values of the list as a single field.
+func (e *Encoder) EnumChars(v StringList) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	var b strings.Builder
+	for _, s := range v.Values {
+		if s.Valid {
+			b.WriteString(s.Value)
+		}
+	}
+	e.RawField(b.String())
+}
+
+// Int64 adds the value of the Int64, an invalid Int64 results in an empty field.
+func (e *Encoder) Int64(v Int64) {
+	e.FixedInt64(v, 0)
+}
+
+// FixedInt64 adds the value of the Int64 padded with leading zeros to the width, e.g. 07 for
+// the month of a ZDA sentence, an invalid Int64 results in an empty field.
+func (e *Encoder) FixedInt64(v Int64, width int) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	if raw, ok := e.original(1, func(fields []string) bool {
+		parsed := ParseInt64(fields[0])
+		return parsed.Valid && parsed.Value == v.Value
+	}); ok {
+		e.RawField(raw...)
+		return
+	}
+	e.RawField(fmt.Sprintf("%0*d", width, v.Value))
+}
+
+// Float64 adds the value of the Float64, an invalid Float64 results in an empty field.
+func (e *Encoder) Float64(v Float64) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	if raw, ok := e.original(1, func(fields []string) bool {
+		parsed := ParseFloat64(fields[0])
+		return parsed.Valid && parsed.Value == v.Value
+	}); ok {
+		e.RawField(raw...)
+		return
+	}
+	e.RawField(strconv.FormatFloat(v.Value, 'f', -1, 64))
+}
+
+// Time adds the Time in hhmmss.ss format, an invalid Time results in an empty field.
+func (e *Encoder) Time(v Time) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	if raw, ok := e.original(1, func(fields []string) bool {
+		return ParseTime(fields[0]) == v
+	}); ok {
+		e.RawField(raw...)
+		return
+	}
+	e.RawField(FormatTime(v))
+}
+
+// Date adds the Date in ddmmyy format, an invalid Date results in an empty field.
+func (e *Encoder) Date(v Date) {
+	if !v.Valid {
+		e.RawField("")
+		return
+	}
+	if raw, ok := e.original(1, func(fields []string) bool {
+		parsed := ParseDate(fields[0])
+		return parsed.Valid && parsed.DD == v.DD && parsed.MM == v.MM && parsed.YY == v.YY%100
+	}); ok {
+		e.RawField(raw...)
+		return
+	}
+	e.RawField(FormatDate(v))
+}
+
+// Latitude adds two fields, the latitude in ddmm.mmmm format and the N or S direction.
+func (e *Encoder) Latitude(v Float64) {
+	e.latLong(v, 2, North, South)
+}
+
+// Longitude adds two fields, the longitude in dddmm.mmmm format and the E or W direction.
+func (e *Encoder) Longitude(v Float64) {
+	e.latLong(v, 3, East, West)
+}
+
+func (e *Encoder) latLong(v Float64, digits int, positive string, negative string) {
+	if !v.Valid {
+		e.RawField("", "")
+		return
+	}
+	if raw, ok := e.original(2, func(fields []string) bool {
+		parsed := parseLatLong(fields[0], fields[1])
+		return parsed.Valid && parsed.Value == v.Value
+	}); ok {
+		e.RawField(raw...)
+		return
+	}
+	direction := positive
+	if v.Value < 0 {
+		direction = negative
+	}
+	e.RawField(formatGPSPadded(v.Value, digits), direction)
+}
+
+// original returns the n fields of the given sentence at the index of the next field when they
+// are not empty and have the same value
+func (e *Encoder) original(n int, same func(fields []string) bool) ([]string, bool) {
+	i := len(e.fields)
+	if i+n > len(e.sentence.Fields) {
+		return nil, false
+	}
+	fields := e.sentence.Fields[i : i+n]
+	for _, f := range fields {
+		if f == "" {
+			return nil, false
+		}
+	}
+	return fields, same(fields)
+}
+
+// SixBitASCIIArmour encodes the bits (one bit per byte) with the 6-bit ascii armour used for VDM and VDO messages,
+// it adds two fields, the encoded payload and the number of fill bits
+func (e *Encoder) SixBitASCIIArmour(bits []byte, context string) {
+	payload, fillBits, err := encodeSixBitASCIIArmour(bits)
+	if err != nil {
+		e.SetErr(context, err.Error())
+		return
+	}
+	e.RawField(payload, strconv.Itoa(fillBits))
+}
+
+// encodeSixBitASCIIArmour encodes the bits (one bit per byte) with the 6-bit ascii armour and
+// returns the armoured payload and the number of fill bits
+func encodeSixBitASCIIArmour(bits []byte) (string, int, error) {
+	fillBits := (6 - len(bits)%6) % 6
+	payload := make([]byte, 0, (len(bits)+fillBits)/6)
+	for i := 0; i < len(bits); i += 6 {
+		var d byte
+		for j := i; j < i+6; j++ {
+			d <<= 1
+			if j < len(bits) {
+				if bits[j] > 1 {
+					return "", 0, errors.New("data bit")
+				}
+				d |= bits[j]
+			}
+		}
+		if d < 40 {
+			d += 48
+		} else {
+			d += 56
+		}
+		payload = append(payload, d)
+	}
+	return string(payload), fillBits, nil
+}
+
+// Encode builds the sentence, including the tag block if it is valid, and calculates the checksum.
+func (e *Encoder) Encode() (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	fieldsRaw := strings.Join(append([]string{e.sentence.Prefix()}, e.fields...), FieldSep)
+	return e.sentence.TagBlock.String() + e.start + fieldsRaw + ChecksumSep + Checksum(fieldsRaw), nil
+}
+
+// formatGPSPadded formats a coordinate in the (d)ddmm.mmmm format used by NMEA sentences,
+// the minutes keep as much precision as needed to be able to parse the exact same value again
+func formatGPSPadded(value float64, digits int) string {
+	value = math.Abs(value)
+	degrees := math.Floor(value)
+	minutes := strconv.FormatFloat((value-degrees)*60, 'f', 8, 64)
+	if strings.HasPrefix(minutes, "60") {
+		degrees++
+		minutes = strconv.FormatFloat(0, 'f', 8, 64)
+	}
+	minutes = strings.TrimRight(minutes, "0")
+	if decimals := len(minutes) - strings.Index(minutes, ".") - 1; decimals < 4 {
+		minutes += strings.Repeat("0", 4-decimals)
+	}
+	if strings.Index(minutes, ".") < 2 {
+		minutes = "0" + minutes
+	}
+	return fmt.Sprintf("%0*d%s", digits, int(degrees), minutes)
+}
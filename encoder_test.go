@@ -0,0 +1,142 @@
+package nmea_test
+
+import (
+	"reflect"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// withoutBaseSentence clears the BaseSentence, the raw payload of proprietary sentences and the reasons
+// of invalid values, the reason of an invalid value changes when a field is encoded as an empty field
+func withoutBaseSentence(s Sentence) interface{} {
+	v := reflect.New(reflect.TypeOf(s)).Elem()
+	v.Set(reflect.ValueOf(s))
+	if proprietary := v.FieldByName("Proprietary"); proprietary.IsValid() {
+		proprietary.Set(reflect.ValueOf(Proprietary{}))
+	}
+	v.FieldByName("BaseSentence").Set(reflect.ValueOf(BaseSentence{}))
+	clearInvalidReasons(v)
+	return v.Interface()
+}
+
+func clearInvalidReasons(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if valid := v.FieldByName("Valid"); valid.IsValid() && valid.Kind() == reflect.Bool && !valid.Bool() {
+			if reason := v.FieldByName("InvalidReason"); reason.IsValid() {
+				reason.SetString("")
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() && v.Type().Field(i).Name != "Packet" {
+				clearInvalidReasons(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearInvalidReasons(v.Index(i))
+		}
+	}
+}
+
+var _ = Describe("Encoder", func() {
+	DescribeTable("Round tripping a sentence through parse, encode and parse",
+		func(raw string, expected ...string) {
+			parsed, err := Parse(raw)
+			Expect(err).ToNot(HaveOccurred())
+			encoded, err := Encode(parsed)
+			Expect(err).ToNot(HaveOccurred())
+			reparsed, err := Parse(encoded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(withoutBaseSentence(reparsed)).To(Equal(withoutBaseSentence(parsed)))
+			Expect(reparsed.Prefix()).To(Equal(parsed.Prefix()))
+			// sentences are encoded as is, except for invalid values and the order of the tag block
+			if len(expected) == 0 {
+				expected = append(expected, raw)
+			}
+			Expect(encoded).To(Equal(expected[0]))
+		},
+		Entry("ALR", "$AIALR,100615.00,002,V,V,AIS: Antenna VSWR exceeds limit*46"),
+		Entry("DBS", "$23DBS,01.9,f,0.58,M,00.3,F*21"),
+		Entry("DBT", "$IIDBT,032.93,f,010.04,M,005.42,F*2C"),
+		Entry("DPT", "$SDDPT,0.5,0.5,0.1*54"),
+		Entry("DPT without range scale", "$SDDPT,0.5,0.5,*7B"),
+		Entry("GGA", "$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,72.5,M,41.5,M,,*7C"),
+		Entry("GGA on the southern hemisphere", "$GNGGA,034225.077,3356.4650,S,15124.5567,E,12,03,9.7,-25.0,M,21.0,M,,0000*7D", "$GNGGA,034225.077,3356.4650,S,15124.5567,E,,03,9.7,-25.0,M,21.0,M,,0000*7E"),
+		Entry("GGA without altitude", "$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,,M,,M,,*7C", "$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,,,,,,*7C"),
+		Entry("GLL", "$GPGLL,3926.7952,N,12000.5947,W,022732,A,A*58"),
+		Entry("GNS", "$GNGNS,014035.00,4332.69262,S,17235.48549,E,RR,13,0.9,25.63,11.24,,*70"),
+		Entry("GNS with multiple modes", "$GNGNS,094821.0,4849.931307,N,00216.053323,E,AAN,14,0.6,161.5,48.0,,*23"),
+		Entry("GSA", "$GPGSA,A,3,22,19,18,27,14,03,,,,,,,3.1,2.0,2.4*36"),
+		Entry("GST", "$GPGST,172814.0,0.006,0.023,0.020,273.6,0.023,0.020,0.031*6A"),
+		Entry("GSV", "$GLGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,12,13,06,292,00*6B"),
+		Entry("HDT", "$GPHDT,123.456,T*32"),
+		Entry("HEV", "$GPHEV,-0.07*54"),
+		Entry("MDA", "$WIMDA,30.1176,I,1.0199,B,44.0,C,12.7,C,78.9,,14.2,C,359.0,T,358.7,M,6.4,N,3.3,M*37"),
+		Entry("MTK", "$PMTK001,604,3*32"),
+		Entry("MWD", "$WIMWD,351.1,T,350.8,M,8.4,N,4.3,M*59"),
+		Entry("MWV", "$WIMWV,117.5,R,4.6,N,A*23"),
+		Entry("PGRME", "$PGRME,3.3,M,4.9,M,6.0,M*25"),
+		Entry("RMC", "$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C"),
+		Entry("RMC with a western variation", "$GNRMC,220516,D,5133.82,N,00042.24,W,173.8,231.8,130694,004.2,W*6B", "$GNRMC,220516,,5133.82,N,00042.24,W,173.8,231.8,130694,004.2,W*2F"),
+		Entry("RMC with a precise position", "$GNRMC,143909.00,A,5107.0020216,N,11402.3294835,W,0.036,348.3,210307,0.0,E,A*31"),
+		Entry("ROT", "$GPROT,3.1,A*33"),
+		Entry("RSA", "$RIRSA,3.1,A,-3.1,A*76"),
+		Entry("RTE", "$IIRTE,4,1,c,Rte 1,411,412,413,414,415*6F"),
+		Entry("THS", "$INTHS,123.456,A*20"),
+		Entry("VDM", "!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52"),
+		Entry("VHW", "$VWVHW,45.0,T,43.0,M,3.5,N,6.4,K*56"),
+		Entry("VTG", "$GPVTG,45.5,T,67.5,M,30.45,N,56.40,K*4B"),
+		Entry("VWR", "$IIVWR,045.0,L,12.6,N,6.5,M,23.3,K*52"),
+		Entry("WPL", "$IIWPL,5503.4530,N,01037.2742,E,411*6F"),
+		Entry("ZDA", "$GPZDA,172809.456,12,07,1996,00,00*57"),
+		Entry("with a tag block", "\\s:Satellite_1,c:1553390539*0E\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03", "\\c:1553390539,s:Satellite_1*0E\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03"),
+	)
+	Describe("Encoding a sentence", func() {
+		Context("when a RMC struct is modified", func() {
+			It("returns the sentence with the new values and checksum", func() {
+				parsed, err := Parse("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C")
+				Expect(err).ToNot(HaveOccurred())
+				rmc := parsed.(RMC)
+				rmc.Speed = NewFloat64(12.5)
+				rmc.Variation = NewInvalidFloat64("unknown")
+				Expect(rmc.Encode()).To(Equal("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,12.5,345.6,230421,,,A,C*02"))
+			})
+		})
+		Context("when a ZDA struct is created", func() {
+			It("returns the fields with their fixed width", func() {
+				zda := ZDA{
+					BaseSentence:  BaseSentence{Talker: "GP", Type: TypeZDA},
+					Time:          NewTime(17, 28, 9, 456),
+					Day:           NewInt64(12),
+					Month:         NewInt64(7),
+					Year:          NewInt64(1996),
+					OffsetHours:   NewInt64(0),
+					OffsetMinutes: NewInt64(0),
+				}
+				Expect(zda.Encode()).To(Equal("$GPZDA,172809.456,12,07,1996,00,00*57"))
+			})
+		})
+		Context("when a sentence has a valid tag block", func() {
+			It("prefixes the tag block", func() {
+				parsed, err := Parse("\\s:Satellite_1,c:1553390539*0E\\$GPHDT,123.456,T*32")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(Encode(parsed)).To(Equal("\\c:1553390539,s:Satellite_1*0E\\$GPHDT,123.456,T*32"))
+			})
+		})
+		Context("when a base sentence is encoded", func() {
+			It("returns the fields as is", func() {
+				bs := BaseSentence{Talker: "GP", Type: "HDT", Fields: []string{"123.456", "T"}}
+				Expect(bs.Encode()).To(Equal("$GPHDT,123.456,T*32"))
+			})
+		})
+		Context("when a sentence can not be encoded", func() {
+			It("returns an error", func() {
+				_, err := Encode(struct{ Sentence }{BaseSentence{Talker: "GP", Type: "XXX"}})
+				Expect(err).To(MatchError("nmea: sentence prefix 'GPXXX' can not be encoded"))
+			})
+		})
+	})
+})
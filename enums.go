@@ -0,0 +1,343 @@
+package nmea
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AISNavigationStatus is the navigation status of a vessel in an AIS position report
+type AISNavigationStatus uint8
+
+// AIS navigation statuses
+const (
+	NavigationStatusUnderWayUsingEngine         AISNavigationStatus = 0
+	NavigationStatusAtAnchor                    AISNavigationStatus = 1
+	NavigationStatusNotUnderCommand             AISNavigationStatus = 2
+	NavigationStatusRestrictedManoeuverability  AISNavigationStatus = 3
+	NavigationStatusConstrainedByDraught        AISNavigationStatus = 4
+	NavigationStatusMoored                      AISNavigationStatus = 5
+	NavigationStatusAground                     AISNavigationStatus = 6
+	NavigationStatusEngagedInFishing            AISNavigationStatus = 7
+	NavigationStatusUnderWaySailing             AISNavigationStatus = 8
+	NavigationStatusHazardousMaterialHighSpeed  AISNavigationStatus = 9
+	NavigationStatusHazardousMaterialWingInGrnd AISNavigationStatus = 10
+	NavigationStatusPowerDrivenVesselTowing     AISNavigationStatus = 11
+	NavigationStatusPowerDrivenVesselPushing    AISNavigationStatus = 12
+	NavigationStatusReserved                    AISNavigationStatus = 13
+	NavigationStatusAISSARTActive               AISNavigationStatus = 14
+	NavigationStatusNotDefined                  AISNavigationStatus = 15
+)
+
+// navigationStatusInfo contains the identifier, the description and the name that is returned by
+// VDMVDO.GetNavigationStatus of the navigation statuses
+var navigationStatusInfo = [...]struct{ identifier, description, name string }{
+	{"motoring", "Under way using engine", "motoring"},
+	{"anchored", "At anchor", "anchored"},
+	{"not-under-command", "Not under command", "not under command"},
+	{"restricted-manoeuverability", "Restricted manoeuverability", "restricted maneuverability"},
+	{"constrained-by-draught", "Constrained by her draught", "constrained by draft"},
+	{"moored", "Moored", "moored"},
+	{"aground", "Aground", "aground"},
+	{"fishing", "Engaged in fishing", "fishing"},
+	{"sailing", "Under way sailing", "sailing"},
+	{"hazardous-material-high-speed", "Reserved for high speed craft carrying dangerous goods", "hazardous material high speed"},
+	{"hazardous-material-wing-in-ground", "Reserved for wing in ground craft carrying dangerous goods", "hazardous material wing in ground"},
+	{"towing", "Power-driven vessel towing astern (regional use)", "reserved for future use"},
+	{"pushing", "Power-driven vessel pushing ahead or towing alongside (regional use)", "reserved for future use"},
+	{"reserved", "Reserved for future use", "reserved for future use"},
+	{"ais-sart", "AIS-SART, MOB-AIS or EPIRB-AIS active", "ais-sart"},
+	{"default", "Not defined", "default"},
+}
+
+// Identifier returns the stable identifier of the navigation status, e.g. motoring
+func (s AISNavigationStatus) Identifier() string {
+	if int(s) < len(navigationStatusInfo) {
+		return navigationStatusInfo[s].identifier
+	}
+	return "unknown"
+}
+
+// Description returns the human readable description of the navigation status
+func (s AISNavigationStatus) Description() string {
+	if int(s) < len(navigationStatusInfo) {
+		return navigationStatusInfo[s].description
+	}
+	return "Unknown navigation status " + strconv.Itoa(int(s))
+}
+
+// String returns the identifier of the navigation status
+func (s AISNavigationStatus) String() string {
+	return s.Identifier()
+}
+
+// AISHazardousCategory is the category of the dangerous goods, harmful substances or marine
+// pollutants carried by a vessel, it is part of the AIS ship type
+type AISHazardousCategory uint8
+
+// AIS hazardous categories
+const (
+	HazardousCategoryNone AISHazardousCategory = 0
+	HazardousCategoryA    AISHazardousCategory = 1
+	HazardousCategoryB    AISHazardousCategory = 2
+	HazardousCategoryC    AISHazardousCategory = 3
+	HazardousCategoryD    AISHazardousCategory = 4
+)
+
+var hazardousCategoryInfo = [...]struct{ identifier, description string }{
+	{"none", "No hazardous category"},
+	{"A", "Hazardous category A, IMO hazard or pollutant category X"},
+	{"B", "Hazardous category B, IMO hazard or pollutant category Y"},
+	{"C", "Hazardous category C, IMO hazard or pollutant category Z"},
+	{"D", "Hazardous category D, IMO hazard or pollutant category OS"},
+}
+
+// Identifier returns the stable identifier of the hazardous category, e.g. A
+func (c AISHazardousCategory) Identifier() string {
+	if int(c) < len(hazardousCategoryInfo) {
+		return hazardousCategoryInfo[c].identifier
+	}
+	return "unknown"
+}
+
+// Description returns the human readable description of the hazardous category
+func (c AISHazardousCategory) Description() string {
+	if int(c) < len(hazardousCategoryInfo) {
+		return hazardousCategoryInfo[c].description
+	}
+	return "Unknown hazardous category " + strconv.Itoa(int(c))
+}
+
+// String returns the identifier of the hazardous category
+func (c AISHazardousCategory) String() string {
+	return c.Identifier()
+}
+
+// AISShipType is the type of ship and cargo of a vessel in AIS static data
+type AISShipType uint8
+
+// AIS ship types, the types of the groups with hazardous categories are the first type of the group
+const (
+	ShipTypeNotAvailable     AISShipType = 0
+	ShipTypeWingInGround     AISShipType = 20
+	ShipTypeFishing          AISShipType = 30
+	ShipTypeTowing           AISShipType = 31
+	ShipTypeTowingLong       AISShipType = 32
+	ShipTypeDredging         AISShipType = 33
+	ShipTypeDiving           AISShipType = 34
+	ShipTypeMilitary         AISShipType = 35
+	ShipTypeSailing          AISShipType = 36
+	ShipTypePleasureCraft    AISShipType = 37
+	ShipTypeHighSpeedCraft   AISShipType = 40
+	ShipTypePilotVessel      AISShipType = 50
+	ShipTypeSearchAndRescue  AISShipType = 51
+	ShipTypeTug              AISShipType = 52
+	ShipTypePortTender       AISShipType = 53
+	ShipTypeAntiPollution    AISShipType = 54
+	ShipTypeLawEnforcement   AISShipType = 55
+	ShipTypeMedicalTransport AISShipType = 58
+	ShipTypeNoncombatant     AISShipType = 59
+	ShipTypePassenger        AISShipType = 60
+	ShipTypeCargo            AISShipType = 70
+	ShipTypeTanker           AISShipType = 80
+	ShipTypeOther            AISShipType = 90
+)
+
+// shipTypeGroups contains the identifier, the description and the name that is returned by
+// VDMVDO.GetVesselType of the groups of ship types
+var shipTypeGroups = map[AISShipType]struct{ identifier, description, name string }{
+	ShipTypeWingInGround:   {"wing-in-ground", "Wing in ground (WIG)", "Wing in ground (WIG)"},
+	ShipTypeHighSpeedCraft: {"high-speed-craft", "High speed craft (HSC)", "High speed craft (HSC)"},
+	ShipTypePassenger:      {"passenger", "Passenger", "Passenger"},
+	ShipTypeCargo:          {"cargo", "Cargo", "Cargo"},
+	ShipTypeTanker:         {"tanker", "Tanker", "Tanker"},
+	ShipTypeOther:          {"other", "Other type", "Other Type"},
+}
+
+// shipTypes contains the identifier, the description and the name that is returned by
+// VDMVDO.GetVesselType of the ship types without a group, reserved and spare types are not valid
+var shipTypes = map[AISShipType]struct {
+	identifier, description, name string
+	reserved                      bool
+}{
+	ShipTypeNotAvailable:     {"not-available", "Not available", "Not available (default)", false},
+	ShipTypeFishing:          {"fishing", "Fishing", "Fishing", false},
+	ShipTypeTowing:           {"towing", "Towing", "Towing", false},
+	ShipTypeTowingLong:       {"towing-long", "Towing, length exceeds 200m or breadth exceeds 25m", "Towing: length exceeds 200m or breadth exceeds 25m", false},
+	ShipTypeDredging:         {"dredging", "Dredging or underwater operations", "Dredging or underwater ops", false},
+	ShipTypeDiving:           {"diving", "Diving operations", "Diving ops", false},
+	ShipTypeMilitary:         {"military", "Military operations", "Military ops", false},
+	ShipTypeSailing:          {"sailing", "Sailing", "Sailing", false},
+	ShipTypePleasureCraft:    {"pleasure-craft", "Pleasure craft", "Pleasure Craft", false},
+	ShipTypePilotVessel:      {"pilot-vessel", "Pilot vessel", "Pilot Vessel", false},
+	ShipTypeSearchAndRescue:  {"search-and-rescue", "Search and rescue vessel", "Search and Rescue vessel", false},
+	ShipTypeTug:              {"tug", "Tug", "Tug", false},
+	ShipTypePortTender:       {"port-tender", "Port tender", "Port Tender", false},
+	ShipTypeAntiPollution:    {"anti-pollution", "Anti-pollution equipment", "Anti-pollution equipment", false},
+	38:                       {"reserved", "Reserved for future use", "Reserved", true},
+	39:                       {"reserved", "Reserved for future use", "Reserved", true},
+	ShipTypeLawEnforcement:   {"law-enforcement", "Law enforcement", "Law Enforcement", false},
+	56:                       {"local-vessel", "Spare, local vessel", "Spare - Local Vessel", true},
+	57:                       {"local-vessel", "Spare, local vessel", "Spare - Local Vessel", true},
+	ShipTypeMedicalTransport: {"medical-transport", "Medical transport", "Medical Transport", false},
+	ShipTypeNoncombatant:     {"noncombatant", "Noncombatant ship according to RR Resolution No. 18", "Noncombatant ship according to RR Resolution No. 18", false},
+}
+
+// Group returns the first type of the group of the ship type without the hazardous category,
+// e.g. ShipTypeCargo for cargo with hazardous category A. Types without a group are returned as is.
+func (t AISShipType) Group() AISShipType {
+	if _, ok := shipTypeGroups[t-t%10]; ok {
+		return t - t%10
+	}
+	return t
+}
+
+// HazardousCategory returns the hazardous category of the ship type
+func (t AISShipType) HazardousCategory() AISHazardousCategory {
+	if t.Group() != t && t%10 <= 4 {
+		return AISHazardousCategory(t % 10)
+	}
+	return HazardousCategoryNone
+}
+
+// Valid returns false for the ship types that are reserved for future use, including the reserved
+// types of a group, and for the spare types for regional use
+func (t AISShipType) Valid() bool {
+	if info, ok := shipTypes[t]; ok {
+		return !info.reserved
+	}
+	if _, ok := shipTypeGroups[t.Group()]; !ok {
+		return false
+	}
+	return !t.groupReserved()
+}
+
+// groupReserved checks if the type is reserved for future use within its group, the types of a
+// group are the hazardous categories and, except for wing in ground, no additional information
+func (t AISShipType) groupReserved() bool {
+	digit := t % 10
+	return digit > 4 && (digit != 9 || t.Group() == ShipTypeWingInGround)
+}
+
+// Identifier returns the stable identifier of the ship type, e.g. cargo or cargo-hazardous-category-a
+func (t AISShipType) Identifier() string {
+	if info, ok := shipTypes[t]; ok {
+		return info.identifier
+	}
+	group, ok := shipTypeGroups[t.Group()]
+	if !ok {
+		return "reserved"
+	}
+	switch digit := t % 10; {
+	case digit == 0:
+		return group.identifier
+	case digit <= 4:
+		return group.identifier + "-hazardous-category-" + strings.ToLower(t.HazardousCategory().Identifier())
+	case !t.groupReserved():
+		return group.identifier + "-no-additional-information"
+	}
+	return group.identifier + "-reserved"
+}
+
+// Description returns the human readable description of the ship type
+func (t AISShipType) Description() string {
+	if info, ok := shipTypes[t]; ok {
+		return info.description
+	}
+	group, ok := shipTypeGroups[t.Group()]
+	if !ok {
+		return "Reserved for future use"
+	}
+	switch digit := t % 10; {
+	case digit == 0:
+		return group.description + ", all ships of this type"
+	case digit <= 4:
+		return group.description + ", hazardous category " + t.HazardousCategory().Identifier()
+	case !t.groupReserved():
+		return group.description + ", no additional information"
+	}
+	return group.description + ", reserved for future use"
+}
+
+// name returns the name of the ship type that is returned by VDMVDO.GetVesselType
+func (t AISShipType) name() string {
+	if info, ok := shipTypes[t]; ok {
+		return info.name
+	}
+	group, ok := shipTypeGroups[t.Group()]
+	if !ok {
+		return "Reserved for future use"
+	}
+	switch digit := t % 10; {
+	case digit == 0:
+		return group.name + ", All ships of this type"
+	case digit <= 4:
+		return group.name + ", Hazardous category " + t.HazardousCategory().Identifier()
+	case !t.groupReserved():
+		return group.name + ", No additional information"
+	}
+	return group.name + ", Reserved for future use"
+}
+
+// String returns the identifier of the ship type
+func (t AISShipType) String() string {
+	return t.Identifier()
+}
+
+// GNSSFixQuality is the quality of a GNSS position fix, e.g. the fix quality of GGA
+type GNSSFixQuality uint8
+
+// GNSS fix qualities
+const (
+	FixQualityInvalid    GNSSFixQuality = 0
+	FixQualityGPS        GNSSFixQuality = 1
+	FixQualityDGPS       GNSSFixQuality = 2
+	FixQualityPPS        GNSSFixQuality = 3
+	FixQualityRTK        GNSSFixQuality = 4
+	FixQualityFloatRTK   GNSSFixQuality = 5
+	FixQualityEstimated  GNSSFixQuality = 6
+	FixQualityManual     GNSSFixQuality = 7
+	FixQualitySimulation GNSSFixQuality = 8
+)
+
+var fixQualityInfo = [...]struct{ identifier, description string }{
+	{"invalid", "Fix not available"},
+	{"gps", "GPS fix"},
+	{"dgps", "Differential GPS fix"},
+	{"pps", "PPS fix"},
+	{"rtk", "Real time kinematic fix"},
+	{"float-rtk", "Float real time kinematic fix"},
+	{"estimated", "Estimated (dead reckoning) fix"},
+	{"manual", "Manual input mode"},
+	{"simulation", "Simulation mode"},
+}
+
+// ParseGNSSFixQuality parses the fix quality field of a sentence, e.g. "2"
+func ParseGNSSFixQuality(s string) (GNSSFixQuality, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || int(v) >= len(fixQualityInfo) {
+		return 0, fmt.Errorf("nmea: invalid fix quality '%s'", s)
+	}
+	return GNSSFixQuality(v), nil
+}
+
+// Identifier returns the stable identifier of the fix quality, e.g. dgps
+func (q GNSSFixQuality) Identifier() string {
+	if int(q) < len(fixQualityInfo) {
+		return fixQualityInfo[q].identifier
+	}
+	return "unknown"
+}
+
+// Description returns the human readable description of the fix quality
+func (q GNSSFixQuality) Description() string {
+	if int(q) < len(fixQualityInfo) {
+		return fixQualityInfo[q].description
+	}
+	return "Unknown fix quality " + strconv.Itoa(int(q))
+}
+
+// String returns the identifier of the fix quality
+func (q GNSSFixQuality) String() string {
+	return q.Identifier()
+}
@@ -0,0 +1,75 @@
+package nmea_test
+
+import (
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AISNavigationStatus", func() {
+	It("has an identifier and a description", func() {
+		Expect(NavigationStatusUnderWayUsingEngine.Identifier()).To(Equal("motoring"))
+		Expect(NavigationStatusUnderWayUsingEngine.Description()).To(Equal("Under way using engine"))
+		Expect(NavigationStatusNotDefined.String()).To(Equal("default"))
+		Expect(uint8(NavigationStatusMoored)).To(Equal(uint8(5)))
+	})
+	It("is unknown when the code is out of range", func() {
+		Expect(AISNavigationStatus(16).Identifier()).To(Equal("unknown"))
+		Expect(AISNavigationStatus(16).Description()).To(Equal("Unknown navigation status 16"))
+	})
+})
+
+var _ = Describe("AISShipType", func() {
+	It("splits the hazardous category from the type", func() {
+		shipType := AISShipType(82)
+		Expect(shipType.Group()).To(Equal(ShipTypeTanker))
+		Expect(shipType.HazardousCategory()).To(Equal(HazardousCategoryB))
+		Expect(shipType.HazardousCategory().Description()).To(Equal("Hazardous category B, IMO hazard or pollutant category Y"))
+		Expect(shipType.Identifier()).To(Equal("tanker-hazardous-category-b"))
+		Expect(shipType.Description()).To(Equal("Tanker, hazardous category B"))
+	})
+	It("has no hazardous category for the other types of a group", func() {
+		Expect(ShipTypePassenger.HazardousCategory()).To(Equal(HazardousCategoryNone))
+		Expect(ShipTypePassenger.Identifier()).To(Equal("passenger"))
+		Expect(AISShipType(69).HazardousCategory()).To(Equal(HazardousCategoryNone))
+		Expect(AISShipType(69).Identifier()).To(Equal("passenger-no-additional-information"))
+		Expect(AISShipType(46).Identifier()).To(Equal("high-speed-craft-reserved"))
+		Expect(AISShipType(29).Identifier()).To(Equal("wing-in-ground-reserved"))
+	})
+	It("returns the types without a group", func() {
+		Expect(ShipTypeTug.Group()).To(Equal(ShipTypeTug))
+		Expect(ShipTypeTug.HazardousCategory()).To(Equal(HazardousCategoryNone))
+		Expect(ShipTypeTug.String()).To(Equal("tug"))
+		Expect(AISShipType(57).Description()).To(Equal("Spare, local vessel"))
+		Expect(ShipTypeNotAvailable.Valid()).To(BeTrue())
+	})
+	It("is not valid when the code is reserved within a group or spare", func() {
+		for _, code := range []int{25, 29, 45, 56, 57, 75, 98} {
+			Expect(AISShipType(code).Valid()).To(BeFalse(), "%d", code)
+		}
+		for _, code := range []int{20, 24, 37, 59, 69, 74, 99} {
+			Expect(AISShipType(code).Valid()).To(BeTrue(), "%d", code)
+		}
+	})
+	It("is reserved when the code has no meaning", func() {
+		for _, code := range []int{1, 19, 38, 39, 100, 255} {
+			Expect(AISShipType(code).Identifier()).To(Equal("reserved"), "%d", code)
+			Expect(AISShipType(code).Valid()).To(BeFalse(), "%d", code)
+		}
+	})
+})
+
+var _ = Describe("GNSSFixQuality", func() {
+	It("parses the fix quality", func() {
+		Expect(ParseGNSSFixQuality(RTK)).To(Equal(FixQualityRTK))
+		Expect(FixQualityRTK.Identifier()).To(Equal("rtk"))
+		Expect(FixQualityEstimated.Description()).To(Equal("Estimated (dead reckoning) fix"))
+		Expect(FixQualityFloatRTK.String()).To(Equal("float-rtk"))
+	})
+	It("returns an error for an invalid fix quality", func() {
+		_, err := ParseGNSSFixQuality("9")
+		Expect(err).To(MatchError("nmea: invalid fix quality '9'"))
+		_, err = ParseGNSSFixQuality("")
+		Expect(err).To(HaveOccurred())
+	})
+})
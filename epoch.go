@@ -0,0 +1,327 @@
+package nmea
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// DefaultEpochTimeout is the default time the EpochAssembler waits for the sentences of an epoch
+	DefaultEpochTimeout = 2 * time.Second
+)
+
+// Fix is the combined information of the sentences of a GNSS epoch, values that are not received
+// in the epoch are invalid
+type Fix struct {
+	Source             string        // The source of the tag block of the sentences
+	TimeOfDay          time.Duration // The UTC time of the fix since midnight
+	Timestamp          time.Time     // The date and time of the fix in UTC, the zero time.Time when the date is unknown
+	Latitude           Float64       // Latitude in degrees
+	Longitude          Float64       // Longitude in degrees
+	Altitude           Float64       // Altitude of the antenna above mean sea level in meters
+	GeoidalSeparation  Float64       // Height of the geoid above the WGS84 ellipsoid in meters
+	SpeedOverGround    Float64       // Speed over ground in meters per second
+	CourseOverGround   Float64       // True course over ground in radians
+	FixQuality         String        // Quality of the fix, see GGA
+	FixType            String        // Type of the fix, see GSA
+	NumberOfSatellites Int64         // Number of satellites in use, see GGA
+	SatellitesUsed     []string      // PRNs of the satellites used for the fix of all GSA sentences
+	SatellitesInView   []GSVInfo     // Visible satellites of all GSV sentences
+	PDOP               Float64       // Position dilution of precision
+	HDOP               Float64       // Horizontal dilution of precision
+	VDOP               Float64       // Vertical dilution of precision
+	SemiMajorError     Float64       // 1 sigma error of the semi-major axis of the error ellipse in meters
+	SemiMinorError     Float64       // 1 sigma error of the semi-minor axis of the error ellipse in meters
+	ErrorOrientation   Float64       // Orientation of the semi-major axis of the error ellipse in radians from true north
+	HorizontalAccuracy Float64       // Estimated horizontal position error in meters, see GST
+	VerticalAccuracy   Float64       // 1 sigma error of the height in meters
+	Sentences          []Sentence    // The sentences of the epoch in the order they are added
+}
+
+// EpochAssemblerConfig contains the options of an EpochAssembler
+type EpochAssemblerConfig struct {
+	// Timeout is the time after which an incomplete epoch is emitted, DefaultEpochTimeout is used
+	// when it is 0 or less
+	Timeout time.Duration
+	// Required are the sentence types, e.g. TypeGGA and TypeRMC, that complete an epoch, a GSV
+	// sentence counts when it is the last message of its sequence. The sentences that follow the
+	// required types, e.g. GST, GSA and GSV, are still added to the completed epoch, so the epoch is
+	// emitted when the next epoch starts or when it times out, unless the required types contain TypeGSA or TypeGSV, then it is emitted as
+	// soon as all of them are received. An epoch without required types is emitted when the next
+	// epoch starts or when it times out.
+	Required []string
+}
+
+// EpochAssembler combines the GGA, RMC, GSA, GSV and GST sentences of a GNSS epoch into a single
+// Fix. Sentences with a time of day, e.g. GGA, RMC and GST, belong to the epoch of their time,
+// sentences without a time of day, e.g. GSA and GSV, belong to the epoch of the sentences with a
+// time of day that are received before them. An epoch is emitted when it is complete, when the
+// next epoch starts or when it times out. Sentences are grouped on the source of the tag block, so the epochs of different
+// receivers do not interfere. The date of the fixes is reconstructed with a Clock per source.
+// An EpochAssembler is safe for concurrent use by multiple goroutines.
+type EpochAssembler struct {
+	config  EpochAssemblerConfig
+	mu      sync.Mutex
+	pending map[string]*epoch
+	clocks  map[string]*Clock
+	emitted map[string]time.Duration
+}
+
+type epoch struct {
+	started   time.Time
+	timed     bool
+	fix       Fix
+	sentences map[string]bool
+}
+
+// NewEpochAssembler constructor
+func NewEpochAssembler(config EpochAssemblerConfig) *EpochAssembler {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultEpochTimeout
+	}
+	return &EpochAssembler{
+		config:  config,
+		pending: map[string]*epoch{},
+		clocks:  map[string]*Clock{},
+		emitted: map[string]time.Duration{},
+	}
+}
+
+// Add adds a sentence received now, see AddAt
+func (a *EpochAssembler) Add(s Sentence) []Fix {
+	return a.AddAt(s, time.Now())
+}
+
+// AddAt adds a sentence received at the given time and returns the fixes of the epochs that are
+// completed or timed out, in the order they are started. Sentences that are not part of an epoch
+// are ignored, as are sentences with the time of day of an epoch that is already emitted.
+func (a *EpochAssembler) AddAt(s Sentence, at time.Time) []Fix {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	fixes := a.expire(at)
+	switch s.(type) {
+	case GGA, RMC, GSA, GSV, GST:
+	default:
+		return fixes
+	}
+
+	source := ""
+	if t, ok := s.(tagBlocker); ok {
+		source = t.tagBlock().Source.Value
+	}
+	var (
+		timeOfDay time.Duration
+		timed     bool
+	)
+	if v, ok := s.(TimeOfDay); ok {
+		if t, err := v.GetTimeOfDay(); err == nil {
+			timeOfDay, timed = t, true
+		}
+	}
+	if emitted, ok := a.emitted[source]; ok && timed && emitted == timeOfDay {
+		return fixes
+	}
+
+	e, ok := a.pending[source]
+	if ok && timed && e.timed && e.fix.TimeOfDay != timeOfDay {
+		fixes = append(fixes, e.fix)
+		delete(a.pending, source)
+		ok = false
+	}
+	if !ok {
+		e = &epoch{started: at, fix: newFix(source), sentences: map[string]bool{}}
+		a.pending[source] = e
+	}
+	if timed && !e.timed {
+		e.timed = true
+		e.fix.TimeOfDay = timeOfDay
+		delete(a.emitted, source)
+	}
+	a.addSentence(e, source, s)
+
+	// sentences without a time of day can still follow the required sentences, the epoch is only
+	// emitted now when they are required themselves
+	if a.complete(e) && (a.required(TypeGSA) || a.required(TypeGSV)) {
+		fixes = append(fixes, e.fix)
+		delete(a.pending, source)
+		if e.timed {
+			a.emitted[source] = e.fix.TimeOfDay
+		}
+	}
+	return fixes
+}
+
+// Expire returns the fixes of the epochs that are timed out at the given time
+func (a *EpochAssembler) Expire(at time.Time) []Fix {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.expire(at)
+}
+
+// Flush returns the fixes of all incomplete epochs, e.g. at the end of the input
+func (a *EpochAssembler) Flush() []Fix {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.emit(func(*epoch) bool { return true })
+}
+
+// Pending returns the number of incomplete epochs
+func (a *EpochAssembler) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+func (a *EpochAssembler) expire(at time.Time) []Fix {
+	return a.emit(func(e *epoch) bool { return at.Sub(e.started) > a.config.Timeout })
+}
+
+// emit removes the epochs that match and returns their fixes in the order they are started
+func (a *EpochAssembler) emit(match func(*epoch) bool) []Fix {
+	var epochs []*epoch
+	for source, e := range a.pending {
+		if match(e) {
+			epochs = append(epochs, e)
+			delete(a.pending, source)
+			if e.timed {
+				a.emitted[source] = e.fix.TimeOfDay
+			}
+		}
+	}
+	sort.SliceStable(epochs, func(i, j int) bool { return epochs[i].started.Before(epochs[j].started) })
+	var fixes []Fix
+	for _, e := range epochs {
+		fixes = append(fixes, e.fix)
+	}
+	return fixes
+}
+
+// required checks if the sentence type is one of the required types
+func (a *EpochAssembler) required(typ string) bool {
+	for _, required := range a.config.Required {
+		if required == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// complete checks if all required sentence types of the epoch are received
+func (a *EpochAssembler) complete(e *epoch) bool {
+	if len(a.config.Required) == 0 {
+		return false
+	}
+	for _, typ := range a.config.Required {
+		if !e.sentences[typ] {
+			return false
+		}
+	}
+	return true
+}
+
+// addSentence adds the values of the sentence to the fix of the epoch
+func (a *EpochAssembler) addSentence(e *epoch, source string, s Sentence) {
+	fix := &e.fix
+	fix.Sentences = append(fix.Sentences, s)
+	e.sentences[s.DataType()] = true
+	if v, ok := s.(GSV); ok {
+		// a GSV sentence is only received when its sequence is complete
+		e.sentences[TypeGSV] = v.MessageNumber.Valid && v.MessageNumber == v.TotalMessages
+	}
+
+	if _, ok := s.(TimeOfDay); ok {
+		clock, ok := a.clocks[source]
+		if !ok {
+			clock = NewClock()
+			a.clocks[source] = clock
+		}
+		if t, err := clock.Time(s); err == nil && (fix.Timestamp.IsZero() || s.DataType() == TypeRMC) {
+			fix.Timestamp = t.UTC()
+		}
+	}
+
+	switch v := s.(type) {
+	case GGA:
+		if latitude, longitude, altitude, err := v.GetPosition3D(); err == nil {
+			fix.Latitude, fix.Longitude, fix.Altitude = NewFloat64(latitude), NewFloat64(longitude), NewFloat64(altitude)
+		}
+		setFixValue(&fix.GeoidalSeparation, v.GetGeoidalSeparation)
+		setFixValue(&fix.HDOP, v.GetHorizontalDilution)
+		if v.FixQuality.Valid {
+			fix.FixQuality = v.FixQuality
+		}
+		if v.NumSatellites.Valid {
+			fix.NumberOfSatellites = v.NumSatellites
+		}
+	case RMC:
+		if latitude, longitude, err := v.GetPosition2D(); err == nil && !fix.Latitude.Valid {
+			fix.Latitude, fix.Longitude = NewFloat64(latitude), NewFloat64(longitude)
+		}
+		setFixValue(&fix.SpeedOverGround, v.GetSpeedOverGround)
+		setFixValue(&fix.CourseOverGround, v.GetTrueCourseOverGround)
+	case GSA:
+		for _, sv := range v.SV {
+			if sv.Valid && sv.Value != "" {
+				fix.SatellitesUsed = append(fix.SatellitesUsed, sv.Value)
+			}
+		}
+		if !fix.FixType.Valid && v.FixType.Valid {
+			fix.FixType = v.FixType
+		}
+		setFixValue(&fix.PDOP, v.GetPositionDilution)
+		setFixValue(&fix.VDOP, v.GetVerticalDilution)
+		if !fix.HDOP.Valid {
+			setFixValue(&fix.HDOP, v.GetHorizontalDilution)
+		}
+	case GSV:
+		fix.SatellitesInView = append(fix.SatellitesInView, v.Info...)
+	case GST:
+		if v.ErrorEllipseSemiMajorAxis1SigmaError.Valid {
+			fix.SemiMajorError = v.ErrorEllipseSemiMajorAxis1SigmaError
+		}
+		if v.ErrorEllipseSemiMinorAxis1SigmaError.Valid {
+			fix.SemiMinorError = v.ErrorEllipseSemiMinorAxis1SigmaError
+		}
+		if orientation, err := v.ErrorEllipseOrientation.GetValue(); err == nil {
+			fix.ErrorOrientation = NewFloat64((unit.Angle(orientation) * unit.Degree).Radians())
+		}
+		setFixValue(&fix.HorizontalAccuracy, v.GetHorizontalAccuracy)
+		setFixValue(&fix.VerticalAccuracy, v.GetVerticalAccuracy)
+	}
+}
+
+// newFix returns a Fix with invalid values
+func newFix(source string) Fix {
+	invalid := NewInvalidFloat64("value is unavailable")
+	return Fix{
+		Source:             source,
+		Latitude:           invalid,
+		Longitude:          invalid,
+		Altitude:           invalid,
+		GeoidalSeparation:  invalid,
+		SpeedOverGround:    invalid,
+		CourseOverGround:   invalid,
+		FixQuality:         NewInvalidString("value is unavailable"),
+		FixType:            NewInvalidString("value is unavailable"),
+		NumberOfSatellites: NewInvalidInt64("value is unavailable"),
+		PDOP:               invalid,
+		HDOP:               invalid,
+		VDOP:               invalid,
+		SemiMajorError:     invalid,
+		SemiMinorError:     invalid,
+		ErrorOrientation:   invalid,
+		HorizontalAccuracy: invalid,
+		VerticalAccuracy:   invalid,
+	}
+}
+
+// setFixValue sets the value of the fix to the value of the getter when it is available
+func setFixValue(value *Float64, getter func() (float64, error)) {
+	if v, err := getter(); err == nil {
+		*value = NewFloat64(v)
+	}
+}
@@ -0,0 +1,203 @@
+package nmea_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EpochAssembler", func() {
+	const (
+		gga     = "GPGGA,114509.30,5142.01288,N,00452.01197,E,1,08,1.0,12.5,M,47.0,M,,"
+		rmc     = "GPRMC,114509.30,A,5142.01288,N,00452.01197,E,10.0,345.6,230421,0.3,E,A"
+		gpgsa   = "GPGSA,A,3,22,19,18,27,14,03,,,,,,,3.1,2.0,2.4"
+		glgsa   = "GLGSA,A,3,65,66,,,,,,,,,,,3.1,2.0,2.4"
+		gsv     = "GPGSV,1,1,02,03,03,111,00,04,15,270,00"
+		gst     = "GPGST,114509.30,1.0,2.0,1.0,90.0,3.0,4.0,5.0"
+		nextGGA = "GPGGA,114510.30,5142.01288,N,00452.01197,E,1,08,1.0,12.5,M,47.0,M,,"
+	)
+	var (
+		assembler *EpochAssembler
+		start     time.Time
+	)
+	parse := func(body string, source string) Sentence {
+		raw := fmt.Sprintf("$%s*%s", body, Checksum(body))
+		if source != "" {
+			tagBlock := "s:" + source
+			raw = fmt.Sprintf("\\%s*%s\\%s", tagBlock, Checksum(tagBlock), raw)
+		}
+		s, err := Parse(raw)
+		Expect(err).ToNot(HaveOccurred())
+		return s
+	}
+	add := func(body string, at time.Time) []Fix {
+		return assembler.AddAt(parse(body, ""), at)
+	}
+	BeforeEach(func() {
+		assembler = NewEpochAssembler(EpochAssemblerConfig{})
+		start = time.Date(2021, 4, 23, 11, 45, 9, 0, time.UTC)
+	})
+	Context("when adding all sentences of an epoch", func() {
+		It("returns the fix when the next epoch starts", func() {
+			for _, body := range []string{gga, gpgsa, glgsa, gsv, gst, rmc} {
+				Expect(add(body, start)).To(BeEmpty())
+			}
+			Expect(assembler.Pending()).To(Equal(1))
+			fixes := add(nextGGA, start.Add(time.Second))
+			Expect(fixes).To(HaveLen(1))
+			fix := fixes[0]
+			Expect(fix.TimeOfDay).To(Equal(11*time.Hour + 45*time.Minute + 9*time.Second + 300*time.Millisecond))
+			Expect(fix.Timestamp).To(Equal(time.Date(2021, 4, 23, 11, 45, 9, 300000000, time.UTC)))
+			Expect(fix.Latitude.Value).To(BeNumerically("~", 51.700215, 0.000001))
+			Expect(fix.Longitude.Value).To(BeNumerically("~", 4.866866, 0.000001))
+			Expect(fix.Altitude).To(Equal(NewFloat64(12.5)))
+			Expect(fix.GeoidalSeparation).To(Equal(NewFloat64(47)))
+			Expect(fix.SpeedOverGround.Value).To(BeNumerically("~", 5.1444, 0.0001))
+			Expect(fix.CourseOverGround.Value).To(BeNumerically("~", 6.031857, 0.000001))
+			Expect(fix.FixQuality).To(Equal(NewString(GPS)))
+			Expect(fix.FixType).To(Equal(NewString(Fix3D)))
+			Expect(fix.NumberOfSatellites).To(Equal(NewInt64(8)))
+			Expect(fix.SatellitesUsed).To(Equal([]string{"22", "19", "18", "27", "14", "03", "65", "66"}))
+			Expect(fix.SatellitesInView).To(HaveLen(2))
+			Expect(fix.PDOP).To(Equal(NewFloat64(3.1)))
+			Expect(fix.HDOP).To(Equal(NewFloat64(1)))
+			Expect(fix.VDOP).To(Equal(NewFloat64(2.4)))
+			Expect(fix.SemiMajorError).To(Equal(NewFloat64(2)))
+			Expect(fix.SemiMinorError).To(Equal(NewFloat64(1)))
+			Expect(fix.ErrorOrientation.Value).To(BeNumerically("~", 1.570796, 0.000001))
+			Expect(fix.HorizontalAccuracy).To(Equal(NewFloat64(5)))
+			Expect(fix.VerticalAccuracy).To(Equal(NewFloat64(5)))
+			Expect(fix.Sentences).To(HaveLen(6))
+			Expect(assembler.Pending()).To(Equal(1))
+		})
+	})
+	Context("when the required sentences are received", func() {
+		BeforeEach(func() {
+			assembler = NewEpochAssembler(EpochAssemblerConfig{Required: []string{TypeGGA, TypeRMC}})
+		})
+		It("adds the sentences with the time of the completed epoch", func() {
+			const (
+				gga1 = "GNGGA,034225.077,3149.631,N,11700.641,E,1,12,1.0,0.0,M,0.0,M,,"
+				rmc1 = "GPRMC,034225.077,A,3149.631,N,11700.641,E,0.0,0.0,010125,,,A"
+				gst1 = "GPGST,034225.077,1.0,2.0,1.0,90.0,3.0,4.0,5.0"
+			)
+			for _, body := range []string{gga1, rmc1, gst1} {
+				Expect(add(body, start)).To(BeEmpty())
+			}
+			fixes := assembler.Flush()
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].Sentences).To(HaveLen(3))
+			Expect(fixes[0].SemiMajorError).To(Equal(NewFloat64(2)))
+		})
+		It("ignores the sentences with the time of the emitted epoch", func() {
+			add(gga, start)
+			add(rmc, start)
+			Expect(assembler.Flush()).To(HaveLen(1))
+			Expect(add(gst, start)).To(BeEmpty())
+			Expect(assembler.Pending()).To(Equal(0))
+		})
+		It("adds the sentences without a time of day to the completed epoch", func() {
+			assembler = NewEpochAssembler(EpochAssemblerConfig{Required: []string{TypeRMC, TypeGGA}})
+			const (
+				rmc1 = "GPRMC,120000.00,A,5142.01288,N,00452.01197,E,10.0,345.6,230421,0.3,E,A"
+				gga1 = "GPGGA,120000.00,5142.01288,N,00452.01197,E,1,03,1.0,12.5,M,47.0,M,,"
+				gsa1 = "GPGSA,A,3,01,02,03,,,,,,,,,,1.5,1.0,1.1"
+				gsv1 = "GPGSV,1,1,03,01,40,083,46,02,17,308,41,03,07,344,39"
+				rmc2 = "GPRMC,120001.00,A,5142.01288,N,00452.01197,E,10.0,345.6,230421,0.3,E,A"
+				gga2 = "GPGGA,120001.00,5142.01288,N,00452.01197,E,1,03,1.0,12.5,M,47.0,M,,"
+			)
+			for _, body := range []string{rmc1, gga1, gsa1, gsv1} {
+				Expect(add(body, start)).To(BeEmpty())
+			}
+			fixes := add(rmc2, start.Add(time.Second))
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].TimeOfDay).To(Equal(12 * time.Hour))
+			Expect(fixes[0].SatellitesUsed).To(Equal([]string{"01", "02", "03"}))
+			Expect(fixes[0].SatellitesInView).To(HaveLen(3))
+			Expect(fixes[0].PDOP).To(Equal(NewFloat64(1.5)))
+			Expect(add(gga2, start.Add(time.Second))).To(BeEmpty())
+			fixes = assembler.Flush()
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].TimeOfDay).To(Equal(12*time.Hour + time.Second))
+			Expect(fixes[0].SatellitesUsed).To(BeEmpty())
+			Expect(fixes[0].SatellitesInView).To(BeEmpty())
+			Expect(fixes[0].PDOP.Valid).To(BeFalse())
+		})
+		It("adds several GSA sentences to the completed epoch", func() {
+			add(gga, start)
+			add(rmc, start)
+			Expect(add(gpgsa, start)).To(BeEmpty())
+			Expect(add(glgsa, start)).To(BeEmpty())
+			fixes := add(nextGGA, start.Add(time.Second))
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].SatellitesUsed).To(Equal([]string{"22", "19", "18", "27", "14", "03", "65", "66"}))
+			Expect(fixes[0].PDOP).To(Equal(NewFloat64(3.1)))
+		})
+	})
+	Context("when GSV is one of the required sentences", func() {
+		BeforeEach(func() {
+			assembler = NewEpochAssembler(EpochAssemblerConfig{Required: []string{TypeGGA, TypeRMC, TypeGSV}})
+		})
+		It("returns the fix at the end of the GSV sequence", func() {
+			add(gga, start)
+			add(rmc, start)
+			add(gpgsa, start)
+			Expect(add("GPGSV,2,1,05,01,40,083,46,02,17,308,41,03,07,344,39,04,15,270,00", start)).To(BeEmpty())
+			fixes := add("GPGSV,2,2,05,05,03,111,00", start)
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].SatellitesInView).To(HaveLen(5))
+			Expect(fixes[0].Sentences).To(HaveLen(5))
+			Expect(assembler.Pending()).To(Equal(0))
+		})
+		It("ignores the sentences with the time of the emitted epoch", func() {
+			add(gga, start)
+			add(rmc, start)
+			add(gsv, start)
+			Expect(add(gst, start)).To(BeEmpty())
+			Expect(assembler.Pending()).To(Equal(0))
+		})
+	})
+	Context("when an epoch times out", func() {
+		It("returns the incomplete fix", func() {
+			add(gga, start)
+			Expect(assembler.Expire(start.Add(DefaultEpochTimeout))).To(BeEmpty())
+			fixes := assembler.Expire(start.Add(DefaultEpochTimeout + time.Millisecond))
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].SpeedOverGround.Valid).To(BeFalse())
+			Expect(fixes[0].Timestamp.IsZero()).To(BeTrue())
+			Expect(assembler.Pending()).To(Equal(0))
+		})
+	})
+	Context("when a date was received in an earlier epoch", func() {
+		It("reconstructs the date of the fix", func() {
+			add(rmc, start)
+			fixes := add(nextGGA, start.Add(time.Second))
+			Expect(fixes).To(HaveLen(1))
+			fixes = assembler.Flush()
+			Expect(fixes).To(HaveLen(1))
+			Expect(fixes[0].Timestamp).To(Equal(time.Date(2021, 4, 23, 11, 45, 10, 300000000, time.UTC)))
+		})
+	})
+	Context("when receiving sentences of different sources", func() {
+		It("assembles the epochs of the sources separately", func() {
+			Expect(assembler.AddAt(parse(gga, "gps1"), start)).To(BeEmpty())
+			Expect(assembler.AddAt(parse(nextGGA, "gps2"), start)).To(BeEmpty())
+			Expect(assembler.AddAt(parse(gpgsa, "gps1"), start)).To(BeEmpty())
+			Expect(assembler.Pending()).To(Equal(2))
+			fixes := assembler.Flush()
+			Expect(fixes).To(HaveLen(2))
+			sources := map[string]Fix{fixes[0].Source: fixes[0], fixes[1].Source: fixes[1]}
+			Expect(sources["gps1"].Sentences).To(HaveLen(2))
+			Expect(sources["gps2"].Sentences).To(HaveLen(1))
+		})
+	})
+	Context("when adding other sentences", func() {
+		It("ignores them", func() {
+			Expect(add("GPHDT,123.456,T", start)).To(BeEmpty())
+			Expect(assembler.Pending()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package nmea
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissingStart is returned when a sentence does not start with a '$' or '!'
+	ErrMissingStart = errors.New("nmea: sentence does not start with a '$' or '!'")
+	// ErrMissingChecksumSeparator is returned when a sentence does not contain the checksum separator
+	ErrMissingChecksumSeparator = errors.New("nmea: sentence does not contain checksum separator")
+	// ErrChecksumMismatch matches a ChecksumError with errors.Is
+	ErrChecksumMismatch = errors.New("nmea: sentence checksum mismatch")
+	// ErrUnsupportedPrefix matches an UnsupportedPrefixError with errors.Is
+	ErrUnsupportedPrefix = errors.New("nmea: sentence prefix not supported")
+	// ErrInvalidField matches a FieldError with errors.Is
+	ErrInvalidField = errors.New("nmea: invalid field")
+)
+
+// ChecksumError is returned when the checksum of a sentence does not match the calculated checksum
+type ChecksumError struct {
+	Expected string // The checksum calculated from the sentence
+	Actual   string // The checksum in the sentence, empty if the sentence has no checksum
+}
+
+// Error implements the error interface
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("nmea: sentence checksum mismatch [%s != %s]", e.Expected, e.Actual)
+}
+
+// Is reports whether the target is ErrChecksumMismatch
+func (e *ChecksumError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// UnsupportedPrefixError is returned when there is no parser for the prefix of a sentence
+type UnsupportedPrefixError struct {
+	Prefix string // The prefix of the sentence, e.g. GPRMC
+}
+
+// Error implements the error interface
+func (e *UnsupportedPrefixError) Error() string {
+	return fmt.Sprintf("nmea: sentence prefix '%s' not supported", e.Prefix)
+}
+
+// Is reports whether the target is ErrUnsupportedPrefix
+func (e *UnsupportedPrefixError) Is(target error) bool {
+	return target == ErrUnsupportedPrefix
+}
+
+// FieldError is returned when a field of a sentence can not be parsed
+type FieldError struct {
+	Prefix  string // The prefix of the sentence, e.g. GPRMC
+	Index   int    // The index of the field, -1 if the error is not about a single field
+	Context string // The description of the field
+	Value   string // The reason or the value that caused the error
+	Raw     string // The raw value of the field, empty if the index is -1
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("nmea: %s invalid %s: %s", e.Prefix, e.Context, e.Value)
+}
+
+// Is reports whether the target is ErrInvalidField
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidField
+}
+
+// Diagnostics is the report of all field errors of a sentence, it is only collected
+// when the "CollectFieldErrors" option is passed to Parse
+type Diagnostics struct {
+	FieldErrors []FieldError // The field errors in the order they were encountered
+}
+
+// diagnoser is implemented by all types that embed a BaseSentence
+type diagnoser interface {
+	diagnostics() *Diagnostics
+}
+
+// FieldErrors returns all field errors of the sentence, nil is returned when the
+// sentence was not parsed with the "CollectFieldErrors" option or has no field errors
+func FieldErrors(s Sentence) []FieldError {
+	d, ok := s.(diagnoser)
+	if !ok || d.diagnostics() == nil {
+		return nil
+	}
+	return d.diagnostics().FieldErrors
+}
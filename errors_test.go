@@ -0,0 +1,65 @@
+package nmea_test
+
+import (
+	"errors"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Errors", func() {
+	Context("when a sentence has a checksum mismatch", func() {
+		It("returns a ChecksumError", func() {
+			_, err := Parse("$GPHDT,123.456,T*33")
+			Expect(errors.Is(err, ErrChecksumMismatch)).To(BeTrue())
+			var checksumError *ChecksumError
+			Expect(errors.As(err, &checksumError)).To(BeTrue())
+			Expect(checksumError.Expected).To(Equal("32"))
+			Expect(checksumError.Actual).To(Equal("33"))
+		})
+	})
+	Context("when a sentence does not start with a '$' or '!'", func() {
+		It("returns ErrMissingStart", func() {
+			_, err := Parse("GPHDT,123.456,T*32")
+			Expect(errors.Is(err, ErrMissingStart)).To(BeTrue())
+		})
+	})
+	Context("when a sentence does not contain a checksum separator", func() {
+		It("returns ErrMissingChecksumSeparator", func() {
+			_, err := Parse("$GPHDT,123.456,T")
+			Expect(errors.Is(err, ErrMissingChecksumSeparator)).To(BeTrue())
+		})
+	})
+	Context("when a sentence prefix is not supported", func() {
+		It("returns an UnsupportedPrefixError", func() {
+			_, err := Parse("$PSTIS,*61")
+			Expect(errors.Is(err, ErrUnsupportedPrefix)).To(BeTrue())
+			var unsupportedPrefixError *UnsupportedPrefixError
+			Expect(errors.As(err, &unsupportedPrefixError)).To(BeTrue())
+			Expect(unsupportedPrefixError.Prefix).To(Equal("PSTIS"))
+		})
+	})
+	Context("when a field can not be parsed", func() {
+		It("returns a FieldError", func() {
+			s, _ := Parse("!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+			p := NewParser(s.(VDMVDO).BaseSentence)
+			p.SixBitASCIIArmour(4, 7, "payload")
+			err := p.Err()
+			Expect(errors.Is(err, ErrInvalidField)).To(BeTrue())
+			var fieldError *FieldError
+			Expect(errors.As(err, &fieldError)).To(BeTrue())
+			Expect(fieldError.Prefix).To(Equal("AIVDM"))
+			Expect(fieldError.Index).To(Equal(4))
+			Expect(fieldError.Value).To(Equal("fill bits"))
+			Expect(fieldError.Raw).To(Equal("13M@ah0025QdPDTCOl`K6`nV00Sv"))
+		})
+	})
+	Context("when comparing with another sentinel", func() {
+		It("does not match", func() {
+			_, err := Parse("$GPHDT,123.456,T*33")
+			Expect(errors.Is(err, ErrUnsupportedPrefix)).To(BeFalse())
+			Expect(errors.Is(err, ErrInvalidField)).To(BeFalse())
+		})
+	})
+})
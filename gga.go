@@ -1,6 +1,9 @@
 package nmea
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	// TypeGGA type for GGA sentences
@@ -77,10 +80,84 @@ func (s GGA) GetPosition3D() (float64, float64, float64, error) {
 	return 0, 0, 0, fmt.Errorf("value is unavailable")
 }
 
-// GetFixQuality retrieves the fix quality from the sentence
+// GetFixQuality retrieves the fix quality code from the sentence
 func (s GGA) GetFixQuality() (string, error) {
 	if v, err := s.FixQuality.GetValue(); err == nil {
 		return v, nil
 	}
 	return "", fmt.Errorf("value is unavailable")
 }
+
+// GetGNSSFixQuality retrieves the fix quality from the sentence
+func (s GGA) GetGNSSFixQuality() (GNSSFixQuality, error) {
+	if v, err := s.FixQuality.GetValue(); err == nil {
+		return ParseGNSSFixQuality(v)
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetHorizontalDilution retrieves the horizontal dilution of precision from the sentence
+func (s GGA) GetHorizontalDilution() (float64, error) {
+	if v, err := s.HDOP.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetAntennaAltitude retrieves the altitude of the antenna above mean sea level in meters from the sentence
+func (s GGA) GetAntennaAltitude() (float64, error) {
+	if v, err := s.Altitude.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetGeoidalSeparation retrieves the geoidal separation in meters from the sentence
+func (s GGA) GetGeoidalSeparation() (float64, error) {
+	if v, err := s.Separation.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetDifferentialAge retrieves the age of the differential corrections in seconds from the sentence
+func (s GGA) GetDifferentialAge() (float64, error) {
+	if v, err := s.DGPSAge.GetValue(); err == nil {
+		return ParseFloat64(v).GetValue()
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s GGA) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the GGA sentence into NMEA 0183 text
+func (s GGA) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.Latitude(s.Latitude)
+	e.Longitude(s.Longitude)
+	e.StringField(s.FixQuality)
+	e.Int64(s.NumSatellites)
+	e.Float64(s.HDOP)
+	e.Float64(s.Altitude)
+	e.RawField(ggaUnit(s.Altitude))
+	e.Float64(s.Separation)
+	e.RawField(ggaUnit(s.Separation))
+	e.StringField(s.DGPSAge)
+	e.StringField(s.DGPSId)
+	return e.Encode()
+}
+
+// ggaUnit returns the unit of the altitude and the separation, it is empty when the value is invalid
+func ggaUnit(v Float64) string {
+	if !v.Valid {
+		return ""
+	}
+	return "M"
+}
@@ -147,6 +147,7 @@ var _ = Describe("GGA", func() {
 			})
 			It("returns a valid fix quality", func() {
 				Expect(parsed.GetFixQuality()).To(Equal(DGPS))
+				Expect(parsed.GetGNSSFixQuality()).To(Equal(FixQualityDGPS))
 			})
 		})
 		Context("when having a struct with a bad fix", func() {
@@ -225,6 +226,31 @@ var _ = Describe("GGA", func() {
 			It("returns an error", func() {
 				_, err := parsed.GetFixQuality()
 				Expect(err).To(HaveOccurred())
+				_, err = parsed.GetGNSSFixQuality()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+		Context("when having a struct with accuracy data", func() {
+			JustBeforeEach(func() {
+				parsed.HDOP = NewFloat64(2.42)
+				parsed.Separation = NewFloat64(41.5)
+				parsed.DGPSAge = NewString("3.5")
+			})
+			It("returns the accuracy data", func() {
+				Expect(parsed.GetHorizontalDilution()).To(Equal(2.42))
+				Expect(parsed.GetAntennaAltitude()).To(Equal(Altitude))
+				Expect(parsed.GetGeoidalSeparation()).To(Equal(41.5))
+				Expect(parsed.GetDifferentialAge()).To(Equal(3.5))
+			})
+		})
+		Context("when having a struct without accuracy data", func() {
+			It("returns an error", func() {
+				_, err := parsed.GetHorizontalDilution()
+				Expect(err).To(HaveOccurred())
+				_, err = parsed.GetGeoidalSeparation()
+				Expect(err).To(HaveOccurred())
+				_, err = parsed.GetDifferentialAge()
+				Expect(err).To(HaveOccurred())
 			})
 		})
 	})
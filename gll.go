@@ -1,6 +1,9 @@
 package nmea
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	// TypeGLL type for GLL sentences
@@ -19,6 +22,7 @@ type GLL struct {
 	Longitude Float64 // Longitude
 	Time      Time    // Time Stamp
 	Validity  String  // validity - A-valid
+	FAAMode   String  // FAA mode indicator, NMEA 2.3 and later
 }
 
 // newGLL constructor
@@ -31,6 +35,7 @@ func newGLL(s BaseSentence) (GLL, error) {
 		Longitude:    p.LatLong(2, 3, "longitude"),
 		Time:         p.Time(4, "time"),
 		Validity:     p.EnumString(5, "validity", ValidGLL, InvalidGLL),
+		FAAMode:      p.OptionalString(6, "FAA mode"),
 	}, p.Err()
 }
 
@@ -45,3 +50,24 @@ func (s GLL) GetPosition2D() (float64, float64, error) {
 	}
 	return 0, 0, fmt.Errorf("value is unavailable")
 }
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s GLL) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the GLL sentence into NMEA 0183 text
+func (s GLL) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Latitude(s.Latitude)
+	e.Longitude(s.Longitude)
+	e.Time(s.Time)
+	e.StringField(s.Validity)
+	if s.FAAMode.Valid {
+		e.StringField(s.FAAMode)
+	}
+	return e.Encode()
+}
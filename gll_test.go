@@ -36,6 +36,7 @@ var _ = Describe("GLL", func() {
 					"Latitude":  Equal(NewFloat64(39.44658666666667)),
 					"Longitude": Equal(NewFloat64(-120.00991166666667)),
 					"Validity":  Equal(NewString(ValidGLL)),
+					"FAAMode":   Equal(NewString("A")),
 				}))
 			})
 		})
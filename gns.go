@@ -1,6 +1,9 @@
 package nmea
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	// TypeGNS type for GNS sentences
@@ -87,3 +90,59 @@ func (s GNS) GetPosition3D() (float64, float64, float64, error) {
 	}
 	return 0, 0, 0, fmt.Errorf("value is unavailable")
 }
+
+// GetHorizontalDilution retrieves the horizontal dilution of precision from the sentence
+func (s GNS) GetHorizontalDilution() (float64, error) {
+	if v, err := s.HDOP.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetAntennaAltitude retrieves the altitude of the antenna above mean sea level in meters from the sentence
+func (s GNS) GetAntennaAltitude() (float64, error) {
+	if v, err := s.Altitude.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetGeoidalSeparation retrieves the geoidal separation in meters from the sentence
+func (s GNS) GetGeoidalSeparation() (float64, error) {
+	if v, err := s.Separation.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetDifferentialAge retrieves the age of the differential corrections in seconds from the sentence
+func (s GNS) GetDifferentialAge() (float64, error) {
+	if v, err := s.Age.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s GNS) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the GNS sentence into NMEA 0183 text
+func (s GNS) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.Latitude(s.Latitude)
+	e.Longitude(s.Longitude)
+	e.EnumChars(s.Mode)
+	e.Int64(s.SVs)
+	e.Float64(s.HDOP)
+	e.Float64(s.Altitude)
+	e.Float64(s.Separation)
+	e.Float64(s.Age)
+	e.Int64(s.Station)
+	return e.Encode()
+}
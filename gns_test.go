@@ -196,5 +196,27 @@ var _ = Describe("GNS", func() {
 				Expect(err).To(HaveOccurred())
 			})
 		})
+		Context("when having a struct with accuracy data", func() {
+			JustBeforeEach(func() {
+				parsed.HDOP = NewFloat64(0.9)
+				parsed.Separation = NewFloat64(11.24)
+				parsed.Age = NewFloat64(2)
+			})
+			It("returns the accuracy data", func() {
+				Expect(parsed.GetHorizontalDilution()).To(Equal(0.9))
+				Expect(parsed.GetAntennaAltitude()).To(Equal(Altitude))
+				Expect(parsed.GetGeoidalSeparation()).To(Equal(11.24))
+				Expect(parsed.GetDifferentialAge()).To(Equal(2.0))
+			})
+		})
+		Context("when having a struct without a differential age", func() {
+			JustBeforeEach(func() {
+				parsed.Age = NewInvalidFloat64("")
+			})
+			It("returns an error", func() {
+				_, err := parsed.GetDifferentialAge()
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
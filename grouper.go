@@ -0,0 +1,181 @@
+package nmea
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGroupTimeout is the default time the Grouper waits for the missing sentences of a group
+	DefaultGroupTimeout = 2 * time.Second
+)
+
+// SentenceGroup is a complete group of sentences that share the same tag block group
+type SentenceGroup struct {
+	TagBlock  TagBlock   // The combined tag block of the sentences, the first value of each parameter is used
+	Sentences []Sentence // The sentences ordered by their sequence number
+}
+
+// Grouper buffers sentences that are part of a tag block group (g parameter) until all sentences
+// of the group are received. Only the first sentence of a group is required to carry the other tag
+// block parameters, such as the source. A Grouper is safe for concurrent use by multiple goroutines.
+type Grouper struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	pending map[groupKey]*pendingGroup
+	evicted uint64
+}
+
+type groupKey struct {
+	source string
+	id     int64
+	total  int64
+}
+
+type pendingGroup struct {
+	started   time.Time
+	sentences []Sentence
+	received  int
+}
+
+// tagBlocker is implemented by all types that embed a BaseSentence
+type tagBlocker interface {
+	tagBlock() TagBlock
+}
+
+// NewGrouper constructor, incomplete groups are evicted after the timeout,
+// DefaultGroupTimeout is used when the timeout is 0 or less
+func NewGrouper(timeout time.Duration) *Grouper {
+	if timeout <= 0 {
+		timeout = DefaultGroupTimeout
+	}
+	return &Grouper{
+		timeout: timeout,
+		pending: map[groupKey]*pendingGroup{},
+	}
+}
+
+// Add adds a sentence received now, see AddAt
+func (g *Grouper) Add(s Sentence) (SentenceGroup, bool, error) {
+	return g.AddAt(s, time.Now())
+}
+
+// AddAt adds a sentence received at the given time. When the sentence completes a group the
+// group is returned together with true. A sentence without a tag block group is returned
+// immediately as a group of one sentence. An error is returned when the sentence is dropped.
+func (g *Grouper) AddAt(s Sentence, at time.Time) (SentenceGroup, bool, error) {
+	var tagBlock TagBlock
+	if t, ok := s.(tagBlocker); ok {
+		tagBlock = t.tagBlock()
+	}
+	if !tagBlock.Valid || !tagBlock.Grouping.Valid {
+		return SentenceGroup{TagBlock: tagBlock, Sentences: []Sentence{s}}, true, nil
+	}
+	if !tagBlock.Group.Valid {
+		return SentenceGroup{}, false, fmt.Errorf("nmea: %s invalid tag block group: %s", s.Prefix(), tagBlock.Group.InvalidReason)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expire(at)
+
+	key := groupKey{
+		source: tagBlock.Source.Value,
+		id:     tagBlock.Group.ID,
+		total:  tagBlock.Group.Total,
+	}
+	key, group, ok := g.group(key)
+	if !ok {
+		group = &pendingGroup{started: at, sentences: make([]Sentence, tagBlock.Group.Total)}
+		g.pending[key] = group
+	}
+	if group.sentences[tagBlock.Group.Sequence-1] != nil {
+		return SentenceGroup{}, false, fmt.Errorf("nmea: %s duplicate sentence in tag block group: %s", s.Prefix(), tagBlock.Grouping.Value)
+	}
+	group.sentences[tagBlock.Group.Sequence-1] = s
+	group.received++
+	if group.received < len(group.sentences) {
+		return SentenceGroup{}, false, nil
+	}
+
+	delete(g.pending, key)
+	return newSentenceGroup(group.sentences), true, nil
+}
+
+// group returns the pending group of the key. Only the first sentence of a group is required to
+// carry the source, so a sentence without a source belongs to the oldest pending group with the
+// same id and total of any source, and a group that was started by sentences without a source is
+// moved to the source of the first sentence with a source.
+func (g *Grouper) group(key groupKey) (groupKey, *pendingGroup, bool) {
+	if group, ok := g.pending[key]; ok {
+		return key, group, true
+	}
+	if key.source != "" {
+		anonymous := groupKey{id: key.id, total: key.total}
+		group, ok := g.pending[anonymous]
+		if ok {
+			delete(g.pending, anonymous)
+			g.pending[key] = group
+		}
+		return key, group, ok
+	}
+	var (
+		found      groupKey
+		foundGroup *pendingGroup
+	)
+	for k, group := range g.pending {
+		if k.id == key.id && k.total == key.total && (foundGroup == nil || group.started.Before(foundGroup.started)) {
+			found, foundGroup = k, group
+		}
+	}
+	if foundGroup == nil {
+		return key, nil, false
+	}
+	return found, foundGroup, true
+}
+
+// Expire evicts the incomplete groups that are older than the timeout at the given time.
+func (g *Grouper) Expire(at time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expire(at)
+}
+
+// Pending returns the number of incomplete groups
+func (g *Grouper) Pending() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.pending)
+}
+
+// Evicted returns the number of incomplete groups that were evicted
+func (g *Grouper) Evicted() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.evicted
+}
+
+func (g *Grouper) expire(at time.Time) {
+	for key, group := range g.pending {
+		if at.Sub(group.started) > g.timeout {
+			delete(g.pending, key)
+			g.evicted++
+		}
+	}
+}
+
+// newSentenceGroup combines the tag blocks of the sentences
+func newSentenceGroup(sentences []Sentence) SentenceGroup {
+	var tagBlock TagBlock
+	for _, s := range sentences {
+		t := s.(tagBlocker).tagBlock()
+		if !tagBlock.Valid {
+			tagBlock = t
+			continue
+		}
+		tagBlock = tagBlock.merge(t)
+	}
+	return SentenceGroup{TagBlock: tagBlock, Sentences: sentences}
+}
@@ -0,0 +1,108 @@
+package nmea_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Grouper", func() {
+	const (
+		first  = "\\g:1-2-73874,n:157036,s:r003669945,c:1241544035*4A\\!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11"
+		second = "\\g:2-2-73874,n:157037*1D\\!AIVDM,2,2,1,B,Q0C@00000000000,2*44"
+	)
+	var (
+		grouper *Grouper
+		start   time.Time
+	)
+	parse := func(raw string) Sentence {
+		s, err := Parse(raw)
+		Expect(err).ToNot(HaveOccurred())
+		return s
+	}
+	BeforeEach(func() {
+		grouper = NewGrouper(time.Second)
+		start = time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	})
+	Context("when adding all sentences of a group", func() {
+		It("returns the complete group with the combined tag block", func() {
+			_, ok, err := grouper.AddAt(parse(second), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(grouper.Pending()).To(Equal(1))
+			group, ok, err := grouper.AddAt(parse(first), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(group.Sentences).To(HaveLen(2))
+			Expect(group.Sentences[0].(VDMVDO).FragmentNumber).To(Equal(NewInt64(1)))
+			Expect(group.Sentences[1].(VDMVDO).FragmentNumber).To(Equal(NewInt64(2)))
+			Expect(group.TagBlock.Source).To(Equal(NewString("r003669945")))
+			Expect(group.TagBlock.Time).To(Equal(NewInt64(1241544035)))
+			Expect(grouper.Pending()).To(Equal(0))
+		})
+	})
+	Context("when adding a sentence without a group", func() {
+		It("returns a group with a single sentence", func() {
+			group, ok, err := grouper.AddAt(parse("$GPHDT,123.456,T*32"), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(group.Sentences).To(HaveLen(1))
+		})
+	})
+	Context("when adding a sentence with an invalid group", func() {
+		It("returns an error", func() {
+			_, ok, err := grouper.AddAt(parse("\\g:3-2-5*69\\$GPHDT,123.456,T*32"), start)
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError("nmea: GPHDT invalid tag block group: parse group: sequence 3 is not in range (1, 2)"))
+		})
+	})
+	Context("when adding a sentence twice", func() {
+		It("returns an error", func() {
+			_, _, err := grouper.AddAt(parse(first), start)
+			Expect(err).ToNot(HaveOccurred())
+			_, ok, err := grouper.AddAt(parse(first), start)
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError("nmea: AIVDM duplicate sentence in tag block group: 1-2-73874"))
+		})
+	})
+	Context("when groups of different sources have the same id", func() {
+		It("keeps the groups apart", func() {
+			withTagBlock := func(tagBlock string) Sentence {
+				return parse(fmt.Sprintf("\\%s*%s\\$GPHDT,123.456,T*32", tagBlock, Checksum(tagBlock)))
+			}
+			_, ok, err := grouper.AddAt(withTagBlock("g:1-2-5,s:r1"), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			_, ok, err = grouper.AddAt(withTagBlock("g:1-2-5,s:r2"), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(grouper.Pending()).To(Equal(2))
+			group, ok, err := grouper.AddAt(withTagBlock("g:2-2-5,s:r2"), start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(group.TagBlock.Source).To(Equal(NewString("r2")))
+			Expect(grouper.Pending()).To(Equal(1))
+		})
+	})
+	Context("when a group has a very large total", func() {
+		It("returns an error", func() {
+			_, ok, err := grouper.AddAt(parse(fmt.Sprintf("\\g:1-100000000000-1*%s\\$GPHDT,123.456,T*32", Checksum("g:1-100000000000-1"))), start)
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError("nmea: GPHDT invalid tag block group: parse group: total 100000000000 is not in range (1, 99)"))
+		})
+	})
+	Context("when a group is not completed in time", func() {
+		It("evicts the group", func() {
+			_, ok, _ := grouper.AddAt(parse(first), start)
+			Expect(ok).To(BeFalse())
+			grouper.Expire(start.Add(2 * time.Second))
+			Expect(grouper.Pending()).To(Equal(0))
+			Expect(grouper.Evicted()).To(Equal(uint64(1)))
+			_, ok, _ = grouper.AddAt(parse(second), start.Add(2*time.Second))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -63,3 +63,45 @@ func (s GSA) GetFixType() (string, error) {
 	}
 	return "", fmt.Errorf("value is unavailable")
 }
+
+// GetHorizontalDilution retrieves the horizontal dilution of precision from the sentence
+func (s GSA) GetHorizontalDilution() (float64, error) {
+	if v, err := s.HDOP.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetPositionDilution retrieves the position dilution of precision from the sentence
+func (s GSA) GetPositionDilution() (float64, error) {
+	if v, err := s.PDOP.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetVerticalDilution retrieves the vertical dilution of precision from the sentence
+func (s GSA) GetVerticalDilution() (float64, error) {
+	if v, err := s.VDOP.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the GSA sentence into NMEA 0183 text
+func (s GSA) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.StringField(s.Mode)
+	e.StringField(s.FixType)
+	for i := 0; i < 12; i++ {
+		if i < len(s.SV) {
+			e.StringField(s.SV[i])
+		} else {
+			e.RawField("")
+		}
+	}
+	e.Float64(s.PDOP)
+	e.Float64(s.HDOP)
+	e.Float64(s.VDOP)
+	return e.Encode()
+}
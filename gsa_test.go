@@ -117,5 +117,27 @@ var _ = Describe("GSA", func() {
 				Expect(err).To(HaveOccurred())
 			})
 		})
+		Context("when having a struct with dilutions of precision", func() {
+			JustBeforeEach(func() {
+				parsed.PDOP = NewFloat64(3.1)
+				parsed.HDOP = NewFloat64(2.0)
+				parsed.VDOP = NewFloat64(2.4)
+			})
+			It("returns the dilutions of precision", func() {
+				Expect(parsed.GetPositionDilution()).To(Equal(3.1))
+				Expect(parsed.GetHorizontalDilution()).To(Equal(2.0))
+				Expect(parsed.GetVerticalDilution()).To(Equal(2.4))
+			})
+		})
+		Context("when having a struct with missing dilutions of precision", func() {
+			It("returns an error", func() {
+				_, err := parsed.GetPositionDilution()
+				Expect(err).To(HaveOccurred())
+				_, err = parsed.GetHorizontalDilution()
+				Expect(err).To(HaveOccurred())
+				_, err = parsed.GetVerticalDilution()
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
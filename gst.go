@@ -1,5 +1,11 @@
 package nmea
 
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
 const (
 	// TypeGST type for GST sentences
 	TypeGST = "GST"
@@ -45,3 +51,44 @@ func newGST(s BaseSentence) (GST, error) {
 	}
 	return m, p.Err()
 }
+
+// GetHorizontalAccuracy retrieves the estimated horizontal position error in meters from the
+// sentence, it is the root of the sum of the squared 1 sigma errors of the latitude and longitude
+func (s GST) GetHorizontalAccuracy() (float64, error) {
+	if latitude, err := s.Latitude1SigmaError.GetValue(); err == nil {
+		if longitude, err := s.Longitude1SigmaError.GetValue(); err == nil {
+			return math.Hypot(latitude, longitude), nil
+		}
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetVerticalAccuracy retrieves the 1 sigma error of the height in meters from the sentence
+func (s GST) GetVerticalAccuracy() (float64, error) {
+	if v, err := s.Height1SigmaError.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s GST) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the GST sentence into NMEA 0183 text
+func (s GST) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.Float64(s.RMSPseudorangeResiduals)
+	e.Float64(s.ErrorEllipseSemiMajorAxis1SigmaError)
+	e.Float64(s.ErrorEllipseSemiMinorAxis1SigmaError)
+	e.Float64(s.ErrorEllipseOrientation)
+	e.Float64(s.Latitude1SigmaError)
+	e.Float64(s.Longitude1SigmaError)
+	e.Float64(s.Height1SigmaError)
+	return e.Encode()
+}
@@ -55,4 +55,30 @@ var _ = Describe("GST", func() {
 			})
 		})
 	})
+	Describe("Getting data from a GST struct", func() {
+		BeforeEach(func() {
+			parsed = GST{
+				Latitude1SigmaError:  NewFloat64(3),
+				Longitude1SigmaError: NewFloat64(4),
+				Height1SigmaError:    NewFloat64(0.031),
+			}
+		})
+		Context("when having a complete struct", func() {
+			It("returns the horizontal accuracy", func() {
+				Expect(parsed.GetHorizontalAccuracy()).To(Equal(5.0))
+			})
+			It("returns the vertical accuracy", func() {
+				Expect(parsed.GetVerticalAccuracy()).To(Equal(0.031))
+			})
+		})
+		Context("when having a struct with a missing longitude error", func() {
+			JustBeforeEach(func() {
+				parsed.Longitude1SigmaError = NewInvalidFloat64("")
+			})
+			It("returns an error", func() {
+				_, err := parsed.GetHorizontalAccuracy()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
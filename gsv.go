@@ -56,3 +56,18 @@ func (s GSV) GetNumberOfSatellites() (int64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the GSV sentence into NMEA 0183 text
+func (s GSV) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Int64(s.TotalMessages)
+	e.Int64(s.MessageNumber)
+	e.Int64(s.NumberSVsInView)
+	for _, info := range s.Info {
+		e.FixedInt64(info.SVPRNNumber, 2)
+		e.FixedInt64(info.Elevation, 2)
+		e.FixedInt64(info.Azimuth, 3)
+		e.FixedInt64(info.SNR, 2)
+	}
+	return e.Encode()
+}
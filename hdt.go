@@ -38,3 +38,15 @@ func (s HDT) GetTrueHeading() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the HDT sentence into NMEA 0183 text
+func (s HDT) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Heading)
+	if s.True {
+		e.RawField("T")
+	} else {
+		e.RawField("")
+	}
+	return e.Encode()
+}
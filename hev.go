@@ -31,3 +31,10 @@ func (s HEV) GetHeave() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the HEV sentence into NMEA 0183 text
+func (s HEV) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Heave)
+	return e.Encode()
+}
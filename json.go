@@ -0,0 +1,450 @@
+package nmea
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// sentenceJSON is the JSON representation of a sentence. The fields are keyed on the name of the
+// struct field, invalid values are null. The units contain the name of the unit of the fields that
+// have a unit, e.g. knots. The manufacturer and sub id are only set for proprietary sentences.
+type sentenceJSON struct {
+	Type         string                     `json:"type"`
+	Talker       string                     `json:"talker"`
+	Manufacturer string                     `json:"manufacturer,omitempty"`
+	SubID        string                     `json:"subid,omitempty"`
+	TagBlock     TagBlock                   `json:"tagblock"`
+	Fields       map[string]json.RawMessage `json:"fields"`
+	Units        map[string]string          `json:"units,omitempty"`
+}
+
+// measurementField is implemented by the fields with a unit character, e.g. Speed
+type measurementField interface {
+	unitNames() map[string]string
+}
+
+var (
+	measurementFieldType = reflect.TypeOf((*measurementField)(nil)).Elem()
+	proprietaryType      = reflect.TypeOf(Proprietary{})
+	vdmvdoType           = reflect.TypeOf(VDMVDO{})
+)
+
+// MarshalSentence encodes the sentence as JSON with the type, the talker, the tag block and the
+// fields of the sentence, proprietary sentences also contain the manufacturer and the sub id. The
+// decoded AIS message of VDM and VDO sentences is one of the fields, the payload is the 6-bit ascii
+// armoured payload with the number of fill bits in FillBits and the fragments are the raw sentences.
+// The units of the fields are taken from the schema of the sentence type and from the unit of the
+// Speed, Length, Temperature and Pressure fields.
+func MarshalSentence(s Sentence) ([]byte, error) {
+	v := reflect.ValueOf(s)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nmea: %T is not a struct", s)
+	}
+	result := sentenceJSON{
+		Type:   s.DataType(),
+		Talker: s.TalkerID(),
+		Fields: map[string]json.RawMessage{},
+		Units:  map[string]string{},
+	}
+	if t, ok := s.(tagBlocker); ok {
+		result.TagBlock = t.tagBlock()
+	}
+	if field := v.FieldByName("Proprietary"); field.IsValid() && field.Type() == proprietaryType {
+		p := field.Interface().(Proprietary)
+		result.Manufacturer, result.SubID = p.Manufacturer, p.SubID
+	}
+	if schema, ok := SchemaOf(s); ok {
+		for _, f := range schema.Fields {
+			if f.Unit != "" {
+				result.Units[f.Name] = f.Unit
+			}
+		}
+	}
+	for _, field := range sentenceFields(v.Type()) {
+		value := v.FieldByIndex(field.Index)
+		if field.Type.Implements(measurementFieldType) {
+			if name, ok := value.Interface().(measurementField).unitNames()[value.FieldByName("Unit").String()]; ok {
+				result.Units[field.Name] = name
+			} else {
+				delete(result.Units, field.Name)
+			}
+			value = value.FieldByName("Float64")
+		}
+		raw, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("nmea: field '%s' of %T: %w", field.Name, s, err)
+		}
+		result.Fields[field.Name] = raw
+	}
+	if m, ok := v.Interface().(VDMVDO); ok {
+		if err := marshalVDMVDO(m, &result); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(result)
+}
+
+// marshalVDMVDO replaces the bits of the payload with the 6-bit ascii armoured payload and the
+// number of fill bits, and the fragments with their raw sentences
+func marshalVDMVDO(m VDMVDO, result *sentenceJSON) error {
+	payload, fillBits, err := encodeSixBitASCIIArmour(m.Payload)
+	if err != nil {
+		return fmt.Errorf("nmea: field 'Payload' of %T: %s", m, err)
+	}
+	fragments := make([]string, len(m.Fragments))
+	for i, fragment := range m.Fragments {
+		fragments[i] = fragment.String()
+	}
+	for name, value := range map[string]interface{}{"Payload": payload, "FillBits": fillBits, "Fragments": fragments} {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("nmea: field '%s' of %T: %w", name, m, err)
+		}
+		result.Fields[name] = raw
+	}
+	if m.Fragments == nil {
+		result.Fields["Fragments"] = json.RawMessage("null")
+	}
+	delete(result.Units, "Payload")
+	return nil
+}
+
+// unmarshalVDMVDO decodes the 6-bit ascii armoured payload and the raw sentences of the fragments
+func unmarshalVDMVDO(envelope sentenceJSON, v reflect.Value) error {
+	var (
+		payload   string
+		fillBits  int
+		fragments []string
+	)
+	for name, target := range map[string]interface{}{"Payload": &payload, "FillBits": &fillBits, "Fragments": &fragments} {
+		if raw, ok := envelope.Fields[name]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return fmt.Errorf("nmea: field '%s': %w", name, err)
+			}
+		}
+	}
+	bits, err := decodeSixBitASCIIArmour(payload, fillBits)
+	if err != nil {
+		return fmt.Errorf("nmea: field 'Payload': %s", err)
+	}
+	v.FieldByName("Payload").Set(reflect.ValueOf(bits))
+	if fragments != nil {
+		sentences := make([]BaseSentence, len(fragments))
+		for i, fragment := range fragments {
+			if sentences[i], err = parseSentence(fragment, ParseConfig{}); err != nil {
+				return fmt.Errorf("nmea: field 'Fragments': %w", err)
+			}
+		}
+		v.FieldByName("Fragments").Set(reflect.ValueOf(sentences))
+	}
+	return nil
+}
+
+// UnmarshalSentence decodes the JSON of MarshalSentence into the struct of the sentence type, the
+// struct is looked up in the schemas of the sentence types. The raw text, fields and checksum of
+// the sentence are set by encoding it, the AIS message of a single fragment VDM or VDO sentence
+// is decoded from the payload.
+func UnmarshalSentence(data []byte) (Sentence, error) {
+	var envelope sentenceJSON
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	goType, ok := defaultRegistry.schemaGoType(envelope.Talker, envelope.Type)
+	if !ok {
+		return nil, fmt.Errorf("nmea: no schema for sentence type '%s'", envelope.Type)
+	}
+	v := reflect.New(goType).Elem()
+	if err := decodeSentence(envelope, v); err != nil {
+		return nil, err
+	}
+	return v.Interface().(Sentence), nil
+}
+
+// unmarshalSentence decodes the JSON of MarshalSentence into the struct the target points to
+func unmarshalSentence(data []byte, target Sentence) error {
+	var envelope sentenceJSON
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(target).Elem()
+	if goType, ok := defaultRegistry.schemaGoType(envelope.Talker, envelope.Type); ok && goType != v.Type() {
+		return fmt.Errorf("nmea: can not unmarshal a %s sentence into a %s", envelope.Type, v.Type())
+	}
+	return decodeSentence(envelope, v)
+}
+
+// schemaGoType returns the struct of the sentence type, proprietary sentence types such as MTK are
+// matched on the prefix of the sentence
+func (r *Registry) schemaGoType(talker, typ string) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if entry, ok := r.schemas[typ]; ok {
+		return entry.goType, true
+	}
+	for _, entry := range r.schemas {
+		if strings.HasPrefix(talker+typ, entry.schema.Type) {
+			return entry.goType, true
+		}
+	}
+	return nil, false
+}
+
+func decodeSentence(envelope sentenceJSON, v reflect.Value) error {
+	for _, field := range sentenceFields(v.Type()) {
+		if field.Type.Kind() == reflect.Interface || (v.Type() == vdmvdoType && (field.Name == "Payload" || field.Name == "Fragments")) {
+			continue
+		}
+		raw, ok := envelope.Fields[field.Name]
+		if !ok {
+			continue
+		}
+		value := v.FieldByIndex(field.Index)
+		if field.Type.Implements(measurementFieldType) {
+			if name, ok := envelope.Units[field.Name]; ok {
+				char, err := unitChar(name, value.Interface().(measurementField).unitNames())
+				if err != nil {
+					return fmt.Errorf("nmea: field '%s': %w", field.Name, err)
+				}
+				value.FieldByName("Unit").SetString(char)
+			}
+			value = value.FieldByName("Float64")
+		}
+		if err := json.Unmarshal(raw, value.Addr().Interface()); err != nil {
+			return fmt.Errorf("nmea: field '%s': %w", field.Name, err)
+		}
+	}
+
+	if v.Type() == vdmvdoType {
+		if err := unmarshalVDMVDO(envelope, v); err != nil {
+			return err
+		}
+	}
+	base := BaseSentence{Talker: envelope.Talker, Type: envelope.Type, TagBlock: envelope.TagBlock}
+	if err := setBaseSentence(v, base, envelope.SubID); err != nil {
+		return err
+	}
+	if m, ok := v.Interface().(VDMVDO); ok && m.NumFragments.Value == 1 && len(m.Payload) > 0 {
+		if packet := aisCodec.DecodePacket(m.Payload); packet != nil {
+			v.FieldByName("Packet").Set(reflect.ValueOf(&packet).Elem())
+		}
+	}
+	v.Set(reflect.ValueOf(withRaw(v.Interface().(Sentence))))
+	return nil
+}
+
+// withRaw returns a copy of the sentence with the raw text, fields and checksum of the encoded
+// sentence, the sentence is returned as is when it can not be encoded
+func withRaw(s Sentence) Sentence {
+	e, ok := s.(Encodable)
+	if !ok {
+		return s
+	}
+	raw, err := e.Encode()
+	if err != nil {
+		return s
+	}
+	encoded, err := parseSentence(raw, ParseConfig{})
+	if err != nil {
+		return s
+	}
+	v := reflect.New(reflect.TypeOf(s)).Elem()
+	v.Set(reflect.ValueOf(s))
+	base := v.FieldByName("BaseSentence").Interface().(BaseSentence)
+	base.Raw, base.Fields, base.Checksum = encoded.Raw, encoded.Fields, encoded.Checksum
+	if err := setBaseSentence(v, base, ""); err != nil {
+		return s
+	}
+	return v.Interface().(Sentence)
+}
+
+// setBaseSentence sets the BaseSentence of the struct, the Proprietary of a proprietary sentence is
+// created from the BaseSentence and gets the sub id when the BaseSentence has no fields yet
+func setBaseSentence(v reflect.Value, base BaseSentence, subID string) error {
+	field := v.FieldByName("Proprietary")
+	if !field.IsValid() || field.Type() != proprietaryType {
+		v.FieldByName("BaseSentence").Set(reflect.ValueOf(base))
+		return nil
+	}
+	p, err := NewProprietary(base)
+	if err != nil {
+		return err
+	}
+	if p.SubID == "" {
+		p.SubID = subID
+	}
+	field.Set(reflect.ValueOf(p))
+	return nil
+}
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s ALR) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *ALR) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s DBS) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *DBS) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s DBT) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *DBT) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s DPT) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *DPT) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GGA) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GGA) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GLL) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GLL) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GNS) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GNS) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GSA) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GSA) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GST) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GST) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s GSV) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *GSV) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s HDT) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *HDT) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s HEV) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *HEV) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s MDA) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *MDA) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s MTK) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *MTK) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s MWD) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *MWD) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s MWV) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *MWV) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s PGRME) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *PGRME) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s RMC) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *RMC) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s ROT) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *ROT) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s RSA) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *RSA) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s RTE) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *RTE) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s THS) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *THS) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s VDMVDO) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *VDMVDO) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s VHW) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *VHW) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s VTG) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *VTG) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s VWR) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *VWR) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s WPL) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *WPL) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
+
+// MarshalJSON encodes the sentence as JSON, see MarshalSentence
+func (s ZDA) MarshalJSON() ([]byte, error) { return MarshalSentence(s) }
+
+// UnmarshalJSON decodes the JSON of MarshalSentence into the sentence
+func (s *ZDA) UnmarshalJSON(data []byte) error { return unmarshalSentence(data, s) }
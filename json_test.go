@@ -0,0 +1,200 @@
+package nmea_test
+
+import (
+	"encoding/json"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// jsonObject decodes the JSON of a sentence into a map
+func jsonObject(data []byte) map[string]interface{} {
+	var result map[string]interface{}
+	ExpectWithOffset(1, json.Unmarshal(data, &result)).To(Succeed())
+	return result
+}
+
+var _ = Describe("JSON", func() {
+	DescribeTable("Round tripping a sentence through JSON",
+		func(raw string) {
+			parsed, err := Parse(raw)
+			Expect(err).ToNot(HaveOccurred())
+			data, err := json.Marshal(parsed)
+			Expect(err).ToNot(HaveOccurred())
+			decoded, err := UnmarshalSentence(data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(withoutBaseSentence(decoded)).To(Equal(withoutBaseSentence(parsed)))
+			Expect(decoded.Prefix()).To(Equal(parsed.Prefix()))
+			encoded, err := Encode(decoded)
+			Expect(err).ToNot(HaveOccurred())
+			reparsed, err := Parse(encoded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(withoutBaseSentence(reparsed)).To(Equal(withoutBaseSentence(parsed)))
+		},
+		Entry("DBS", "$23DBS,01.9,f,0.58,M,00.3,F*21"),
+		Entry("DPT", "$SDDPT,0.5,0.5,0.1*54"),
+		Entry("GGA", "$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,72.5,M,41.5,M,,*7C"),
+		Entry("GNS", "$GNGNS,014035.00,4332.69262,S,17235.48549,E,RR,13,0.9,25.63,11.24,,*70"),
+		Entry("GSA", "$GPGSA,A,3,22,19,18,27,14,03,,,,,,,3.1,2.0,2.4*36"),
+		Entry("GSV", "$GLGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,12,13,06,292,00*6B"),
+		Entry("MDA", "$WIMDA,30.1176,I,1.0199,B,44.0,C,12.7,C,78.9,,14.2,C,359.0,T,358.7,M,6.4,N,3.3,M*37"),
+		Entry("MTK", "$PMTK001,604,3*32"),
+		Entry("MWV", "$WIMWV,117.5,R,4.6,N,A*23"),
+		Entry("PGRME", "$PGRME,3.3,M,4.9,M,6.0,M*25"),
+		Entry("RMC", "$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C"),
+		Entry("RTE", "$IIRTE,4,1,c,Rte 1,411,412,413,414,415*6F"),
+		Entry("VDM", "!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52"),
+		Entry("VTG", "$GPVTG,45.5,T,67.5,M,30.45,N,56.40,K*4B"),
+		Entry("ZDA", "$GPZDA,172809.456,12,07,1996,00,00*57"),
+		Entry("with a tag block", "\\s:Satellite_1,c:1553390539*0E\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03"),
+	)
+
+	Describe("Marshalling a sentence", func() {
+		Context("when a RMC sentence is marshalled", func() {
+			var object map[string]interface{}
+			BeforeEach(func() {
+				parsed, err := Parse("$GNRMC,220516,D,5133.82,N,00042.24,W,173.8,231.8,130694,,*14")
+				Expect(err).ToNot(HaveOccurred())
+				data, err := json.Marshal(parsed)
+				Expect(err).ToNot(HaveOccurred())
+				object = jsonObject(data)
+			})
+			It("contains the metadata of the sentence", func() {
+				Expect(object).To(HaveKeyWithValue("type", "RMC"))
+				Expect(object).To(HaveKeyWithValue("talker", "GN"))
+				Expect(object).To(HaveKeyWithValue("tagblock", BeNil()))
+			})
+			It("contains the values of the fields", func() {
+				fields := object["fields"].(map[string]interface{})
+				Expect(fields).To(HaveKeyWithValue("Time", "22:05:16.0000"))
+				Expect(fields).To(HaveKeyWithValue("Date", "13/06/94"))
+				Expect(fields).To(HaveKeyWithValue("Speed", 173.8))
+				Expect(fields).To(HaveKeyWithValue("Latitude", BeNumerically("~", 51.5637, 0.0001)))
+			})
+			It("encodes invalid values as null", func() {
+				fields := object["fields"].(map[string]interface{})
+				Expect(fields).To(HaveKeyWithValue("Variation", BeNil()))
+			})
+			It("names the units of the fields", func() {
+				Expect(object["units"]).To(HaveKeyWithValue("Speed", "knots"))
+			})
+		})
+		Context("when a MWV sentence is marshalled", func() {
+			It("names the unit of the speed", func() {
+				parsed, err := Parse("$WIMWV,117.5,R,4.6,M,A*20")
+				Expect(err).ToNot(HaveOccurred())
+				data, err := MarshalSentence(parsed)
+				Expect(err).ToNot(HaveOccurred())
+				object := jsonObject(data)
+				Expect(object["units"]).To(HaveKeyWithValue("WindSpeed", "meters per second"))
+				Expect(object["fields"]).To(HaveKeyWithValue("WindSpeed", 4.6))
+			})
+		})
+		Context("when a VDM sentence with a tag block is marshalled", func() {
+			var object map[string]interface{}
+			BeforeEach(func() {
+				parsed, err := Parse("\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				Expect(err).ToNot(HaveOccurred())
+				data, err := json.Marshal(parsed)
+				Expect(err).ToNot(HaveOccurred())
+				object = jsonObject(data)
+			})
+			It("contains the tag block", func() {
+				Expect(object["tagblock"]).To(HaveKeyWithValue("source", "Satellite_1"))
+				Expect(object["tagblock"]).To(HaveKeyWithValue("time", BeNumerically("==", 1553390539)))
+			})
+			It("contains the decoded AIS message", func() {
+				packet := object["fields"].(map[string]interface{})["Packet"]
+				Expect(packet).To(HaveKeyWithValue("UserID", BeNumerically("==", 232008128)))
+			})
+			It("contains the armoured payload", func() {
+				Expect(object["fields"]).To(HaveKeyWithValue("Payload", "13M@ah0025QdPDTCOl`K6`nV00Sv"))
+				Expect(object["fields"]).To(HaveKeyWithValue("FillBits", BeNumerically("==", 0)))
+				Expect(object).ToNot(HaveKey("units"))
+			})
+		})
+		Context("when a proprietary sentence is marshalled", func() {
+			var object map[string]interface{}
+			BeforeEach(func() {
+				parsed, err := Parse("$PGRME,3.3,M,4.9,M,6.0,M*25")
+				Expect(err).ToNot(HaveOccurred())
+				data, err := json.Marshal(parsed)
+				Expect(err).ToNot(HaveOccurred())
+				object = jsonObject(data)
+			})
+			It("contains the manufacturer and the sub id", func() {
+				Expect(object).To(HaveKeyWithValue("talker", "P"))
+				Expect(object).To(HaveKeyWithValue("manufacturer", "GRM"))
+				Expect(object).To(HaveKeyWithValue("subid", "E"))
+			})
+			It("does not contain the embedded sentence", func() {
+				Expect(object["fields"]).To(HaveLen(3))
+				Expect(object["fields"]).ToNot(HaveKey("Proprietary"))
+			})
+		})
+	})
+
+	Describe("Unmarshalling a sentence", func() {
+		Context("when the sentence type has no schema", func() {
+			It("returns an error", func() {
+				_, err := UnmarshalSentence([]byte(`{"type":"XYZ","talker":"GP","tagblock":null,"fields":{}}`))
+				Expect(err).To(MatchError("nmea: no schema for sentence type 'XYZ'"))
+			})
+		})
+		Context("when the sentence is unmarshalled into a struct of another sentence type", func() {
+			It("returns an error", func() {
+				var gga GGA
+				err := json.Unmarshal([]byte(`{"type":"RMC","talker":"GP","tagblock":null,"fields":{}}`), &gga)
+				Expect(err).To(MatchError("nmea: can not unmarshal a RMC sentence into a nmea.GGA"))
+			})
+		})
+		Context("when the unit is unknown", func() {
+			It("returns an error", func() {
+				_, err := UnmarshalSentence([]byte(`{"type":"MWV","talker":"WI","tagblock":null,"fields":{"WindSpeed":4.6},"units":{"WindSpeed":"furlongs per fortnight"}}`))
+				Expect(err).To(MatchError("nmea: field 'WindSpeed': nmea: unknown unit 'furlongs per fortnight'"))
+			})
+		})
+		Context("when the JSON is written by hand", func() {
+			It("sets the raw text of the sentence", func() {
+				var hdt HDT
+				Expect(json.Unmarshal([]byte(`{"type":"HDT","talker":"GP","tagblock":null,"fields":{"Heading":123.456,"True":true}}`), &hdt)).To(Succeed())
+				Expect(hdt.Heading).To(Equal(NewFloat64(123.456)))
+				Expect(hdt.String()).To(Equal("$GPHDT,123.456,T*32"))
+			})
+		})
+	})
+
+	Describe("Marshalling a measurement", func() {
+		It("encodes the value and the name of the unit", func() {
+			Expect(json.Marshal(NewSpeed(4.6, SpeedUnitKnots))).To(MatchJSON(`{"value":4.6,"unit":"knots"}`))
+		})
+		It("encodes an invalid value as null", func() {
+			Expect(json.Marshal(NewInvalidLength("empty"))).To(MatchJSON(`null`))
+		})
+		It("decodes the value and the unit", func() {
+			var t Temperature
+			Expect(json.Unmarshal([]byte(`{"value":12.7,"unit":"degrees Celsius"}`), &t)).To(Succeed())
+			Expect(t).To(Equal(NewTemperature(12.7, TemperatureUnitCelsius)))
+		})
+	})
+
+	Describe("Marshalling a time and a date", func() {
+		It("round trips a time", func() {
+			data, err := json.Marshal(NewTime(17, 28, 9, 456))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(MatchJSON(`"17:28:09.4560"`))
+			var t Time
+			Expect(json.Unmarshal(data, &t)).To(Succeed())
+			Expect(t).To(Equal(NewTime(17, 28, 9, 456)))
+		})
+		It("round trips a date", func() {
+			data, err := json.Marshal(NewDate(21, 4, 23))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(MatchJSON(`"23/04/21"`))
+			var d Date
+			Expect(json.Unmarshal(data, &d)).To(Succeed())
+			Expect(d).To(Equal(NewDate(21, 4, 23)))
+		})
+	})
+})
@@ -36,16 +36,16 @@ const (
 // MDA - Meteorological Composite
 type MDA struct {
 	BaseSentence
-	BarometricPressureInInchesOfMercury Float64
-	BarometricPressureInBar             Float64
-	AirTemperature                      Float64
-	WaterTemperature                    Float64
+	BarometricPressureInInchesOfMercury Pressure
+	BarometricPressureInBar             Pressure
+	AirTemperature                      Temperature
+	WaterTemperature                    Temperature
 	RelativeHumidity                    Float64
-	DewPoint                            Float64
+	DewPoint                            Temperature
 	WindDirectionTrue                   Float64
 	WindDirectionMagnetic               Float64
-	WindSpeedInKnots                    Float64
-	WindSpeedInMetersPerSecond          Float64
+	WindSpeedInKnots                    Speed
+	WindSpeedInMetersPerSecond          Speed
 }
 
 // newMDA constructor
@@ -54,16 +54,16 @@ func newMDA(s BaseSentence) (MDA, error) {
 	p.AssertType(TypeMDA)
 	m := MDA{
 		BaseSentence:                        s,
-		BarometricPressureInInchesOfMercury: p.Float64(0, "BarometricPressureInInchesOfMercury"),
-		BarometricPressureInBar:             p.Float64(2, "BarometricPressureInBar"),
-		AirTemperature:                      p.Float64(4, "AirTemperature"),
-		WaterTemperature:                    p.Float64(6, "WaterTemperature"),
+		BarometricPressureInInchesOfMercury: p.Pressure(0, 1, "BarometricPressureInInchesOfMercury", PressureUnitInchesOfMercury),
+		BarometricPressureInBar:             p.Pressure(2, 3, "BarometricPressureInBar", PressureUnitBar),
+		AirTemperature:                      p.Temperature(4, 5, "AirTemperature", TemperatureUnitCelsius),
+		WaterTemperature:                    p.Temperature(6, 7, "WaterTemperature", TemperatureUnitCelsius),
 		RelativeHumidity:                    p.Float64(8, "RelativeHumidity"),
-		DewPoint:                            p.Float64(10, "DewPoint"),
+		DewPoint:                            p.Temperature(10, 11, "DewPoint", TemperatureUnitCelsius),
 		WindDirectionTrue:                   p.Float64(12, "WindDirectionTrue"),
 		WindDirectionMagnetic:               p.Float64(14, "WindDirectionMagnetic"),
-		WindSpeedInKnots:                    p.Float64(16, "WindSpeedInKnots"),
-		WindSpeedInMetersPerSecond:          p.Float64(18, "WindSpeedInMetersPerSecond"),
+		WindSpeedInKnots:                    p.Speed(16, 17, "WindSpeedInKnots", SpeedUnitKnots),
+		WindSpeedInMetersPerSecond:          p.Speed(18, 19, "WindSpeedInMetersPerSecond", SpeedUnitMetersPerSecond),
 	}
 	return m, p.Err()
 }
@@ -86,46 +86,44 @@ func (s MDA) GetMagneticWindDirection() (float64, error) {
 
 // GetWindSpeed retrieves wind speed from the sentence
 func (s MDA) GetWindSpeed() (float64, error) {
-	if v, err := s.WindSpeedInMetersPerSecond.GetValue(); err == nil {
-		return v, nil
-	}
-	if v, err := s.WindSpeedInKnots.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
+	for _, speed := range []Speed{s.WindSpeedInMetersPerSecond, s.WindSpeedInKnots} {
+		if v, err := speed.GetSpeed(); err == nil {
+			return v.MetersPerSecond(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetOutsideTemperature retrieves the outside air temperature from the sentence
 func (s MDA) GetOutsideTemperature() (float64, error) {
-	if v, err := s.AirTemperature.GetValue(); err == nil {
-		return unit.FromCelsius(v).Kelvin(), nil
+	if v, err := s.AirTemperature.GetTemperature(); err == nil {
+		return v.Kelvin(), nil
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetWaterTemperature retrieves the outside air temperature from the sentence
 func (s MDA) GetWaterTemperature() (float64, error) {
-	if v, err := s.WaterTemperature.GetValue(); err == nil {
-		return unit.FromCelsius(v).Kelvin(), nil
+	if v, err := s.WaterTemperature.GetTemperature(); err == nil {
+		return v.Kelvin(), nil
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetDewPointTemperature retrieves the dew point temperature from the sentence
 func (s MDA) GetDewPointTemperature() (float64, error) {
-	if v, err := s.DewPoint.GetValue(); err == nil {
-		return unit.FromCelsius(v).Kelvin(), nil
+	if v, err := s.DewPoint.GetTemperature(); err == nil {
+		return v.Kelvin(), nil
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetOutsidePressure retrieves the outside pressure from the sentence
 func (s MDA) GetOutsidePressure() (float64, error) {
-	if v, err := s.BarometricPressureInBar.GetValue(); err == nil {
-		return (unit.Pressure(v) * unit.Bar).Pascals(), nil
-	}
-	if v, err := s.BarometricPressureInInchesOfMercury.GetValue(); err == nil {
-		return (unit.Pressure(v) * unit.InchOfMercury).Pascals(), nil
+	for _, pressure := range []Pressure{s.BarometricPressureInBar, s.BarometricPressureInInchesOfMercury} {
+		if v, err := pressure.GetPressure(); err == nil {
+			return v.Pascals(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
@@ -137,3 +135,29 @@ func (s MDA) GetHumidity() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the MDA sentence into NMEA 0183 text
+func (s MDA) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.BarometricPressureInInchesOfMercury.Float64)
+	e.RawField(PressureUnitInchesOfMercury)
+	e.Float64(s.BarometricPressureInBar.Float64)
+	e.RawField(PressureUnitBar)
+	e.Float64(s.AirTemperature.Float64)
+	e.RawField(TemperatureUnitCelsius)
+	e.Float64(s.WaterTemperature.Float64)
+	e.RawField(TemperatureUnitCelsius)
+	e.Float64(s.RelativeHumidity)
+	e.RawField("")
+	e.Float64(s.DewPoint.Float64)
+	e.RawField(TemperatureUnitCelsius)
+	e.Float64(s.WindDirectionTrue)
+	e.RawField("T")
+	e.Float64(s.WindDirectionMagnetic)
+	e.RawField("M")
+	e.Float64(s.WindSpeedInKnots.Float64)
+	e.RawField(SpeedUnitKnots)
+	e.Float64(s.WindSpeedInMetersPerSecond.Float64)
+	e.RawField(SpeedUnitMetersPerSecond)
+	return e.Encode()
+}
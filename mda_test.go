@@ -32,16 +32,16 @@ var _ = Describe("MDA", func() {
 			})
 			It("equals a valid MDA struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"BarometricPressureInInchesOfMercury": Equal(NewFloat64(30.1176)),
-					"BarometricPressureInBar":             Equal(NewFloat64(1.0199)),
-					"AirTemperature":                      Equal(NewFloat64(44)),
-					"WaterTemperature":                    Equal(NewFloat64(12.7)),
+					"BarometricPressureInInchesOfMercury": Equal(NewPressure(30.1176, PressureUnitInchesOfMercury)),
+					"BarometricPressureInBar":             Equal(NewPressure(1.0199, PressureUnitBar)),
+					"AirTemperature":                      Equal(NewTemperature(44, TemperatureUnitCelsius)),
+					"WaterTemperature":                    Equal(NewTemperature(12.7, TemperatureUnitCelsius)),
 					"RelativeHumidity":                    Equal(NewFloat64(78.9)),
-					"DewPoint":                            Equal(NewFloat64(14.2)),
+					"DewPoint":                            Equal(NewTemperature(14.2, TemperatureUnitCelsius)),
 					"WindDirectionTrue":                   Equal(NewFloat64(359)),
 					"WindDirectionMagnetic":               Equal(NewFloat64(358.7)),
-					"WindSpeedInKnots":                    Equal(NewFloat64(6.4)),
-					"WindSpeedInMetersPerSecond":          Equal(NewFloat64(3.3)),
+					"WindSpeedInKnots":                    Equal(NewSpeed(6.4, SpeedUnitKnots)),
+					"WindSpeedInMetersPerSecond":          Equal(NewSpeed(3.3, SpeedUnitMetersPerSecond)),
 				}))
 			})
 		})
@@ -60,16 +60,16 @@ var _ = Describe("MDA", func() {
 	Describe("Getting data from a MDA struct", func() {
 		BeforeEach(func() {
 			parsed = MDA{
-				BarometricPressureInInchesOfMercury: NewFloat64(PressureInchesOfMercury),
-				BarometricPressureInBar:             NewFloat64(PressureBar),
-				AirTemperature:                      NewFloat64(AirTemperatureCelsius),
-				WaterTemperature:                    NewFloat64(WaterTemperatureCelsius),
+				BarometricPressureInInchesOfMercury: NewPressure(PressureInchesOfMercury, PressureUnitInchesOfMercury),
+				BarometricPressureInBar:             NewPressure(PressureBar, PressureUnitBar),
+				AirTemperature:                      NewTemperature(AirTemperatureCelsius, TemperatureUnitCelsius),
+				WaterTemperature:                    NewTemperature(WaterTemperatureCelsius, TemperatureUnitCelsius),
 				RelativeHumidity:                    NewFloat64(RelativeHumidityPercentage),
-				DewPoint:                            NewFloat64(DewPointCelsius),
+				DewPoint:                            NewTemperature(DewPointCelsius, TemperatureUnitCelsius),
 				WindDirectionTrue:                   NewFloat64(TrueDirectionDegrees),
 				WindDirectionMagnetic:               NewFloat64(MagneticDirectionDegrees),
-				WindSpeedInKnots:                    NewFloat64(SpeedOverGroundKnots),
-				WindSpeedInMetersPerSecond:          NewFloat64(SpeedOverGroundMPS),
+				WindSpeedInKnots:                    NewSpeed(SpeedOverGroundKnots, SpeedUnitKnots),
+				WindSpeedInMetersPerSecond:          NewSpeed(SpeedOverGroundMPS, SpeedUnitMetersPerSecond),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -100,7 +100,7 @@ var _ = Describe("MDA", func() {
 		})
 		Context("when having a struct with pressure inches of mercury missing", func() {
 			JustBeforeEach(func() {
-				parsed.BarometricPressureInInchesOfMercury = NewInvalidFloat64("")
+				parsed.BarometricPressureInInchesOfMercury = NewInvalidPressure("")
 			})
 			It("returns a valid outside pressure", func() {
 				Expect(parsed.GetOutsidePressure()).To(BeNumerically("~", PressurePascal, 0.00001))
@@ -108,7 +108,7 @@ var _ = Describe("MDA", func() {
 		})
 		Context("when having a struct with pressure bar missing", func() {
 			JustBeforeEach(func() {
-				parsed.BarometricPressureInBar = NewInvalidFloat64("")
+				parsed.BarometricPressureInBar = NewInvalidPressure("")
 			})
 			It("returns a valid outside pressure", func() {
 				Expect(parsed.GetOutsidePressure()).To(BeNumerically("~", PressurePascal, 0.5))
@@ -116,7 +116,7 @@ var _ = Describe("MDA", func() {
 		})
 		Context("when having a struct with wind speed in meters per second missing", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInMetersPerSecond = NewInvalidFloat64("")
+				parsed.WindSpeedInMetersPerSecond = NewInvalidSpeed("")
 			})
 			It("returns a valid outside pressure", func() {
 				Expect(parsed.GetWindSpeed()).To(BeNumerically("~", SpeedOverGroundMPS, 0.00001))
@@ -0,0 +1,289 @@
+package nmea
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Unit characters of the measurements, the same character can have a different meaning
+// for a different quantity, e.g. M is meters for a length and meters per second for a speed
+const (
+	SpeedUnitKnots             = "N"
+	SpeedUnitKilometersPerHour = "K"
+	SpeedUnitMetersPerSecond   = "M"
+	SpeedUnitMilesPerHour      = "S"
+
+	LengthUnitMeters  = "M"
+	LengthUnitFeet    = "f"
+	LengthUnitFathoms = "F"
+
+	TemperatureUnitCelsius    = "C"
+	TemperatureUnitFahrenheit = "F"
+	TemperatureUnitKelvin     = "K"
+
+	PressureUnitBar             = "B"
+	PressureUnitInchesOfMercury = "I"
+	PressureUnitPascal          = "P"
+)
+
+var (
+	speedUnitNames = map[string]string{
+		SpeedUnitKnots:             UnitKnots,
+		SpeedUnitKilometersPerHour: UnitKilometersPerHour,
+		SpeedUnitMetersPerSecond:   UnitMetersPerSecond,
+		SpeedUnitMilesPerHour:      UnitMilesPerHour,
+	}
+	lengthUnitNames = map[string]string{
+		LengthUnitMeters:  UnitMeters,
+		LengthUnitFeet:    UnitFeet,
+		LengthUnitFathoms: UnitFathoms,
+	}
+	temperatureUnitNames = map[string]string{
+		TemperatureUnitCelsius:    UnitDegreesCelsius,
+		TemperatureUnitFahrenheit: UnitDegreesFahrenheit,
+		TemperatureUnitKelvin:     UnitKelvin,
+	}
+	pressureUnitNames = map[string]string{
+		PressureUnitBar:             UnitBar,
+		PressureUnitInchesOfMercury: UnitInchesOfMercury,
+		PressureUnitPascal:          UnitPascal,
+	}
+	speedUnits = map[string]unit.Speed{
+		SpeedUnitKnots:             unit.Knot,
+		SpeedUnitKilometersPerHour: unit.KilometersPerHour,
+		SpeedUnitMetersPerSecond:   unit.MetersPerSecond,
+		SpeedUnitMilesPerHour:      unit.MilesPerHour,
+	}
+	lengthUnits = map[string]unit.Length{
+		LengthUnitMeters:  unit.Meter,
+		LengthUnitFeet:    unit.Foot,
+		LengthUnitFathoms: unit.Fathom,
+	}
+	pressureUnits = map[string]unit.Pressure{
+		PressureUnitBar:             unit.Bar,
+		PressureUnitInchesOfMercury: unit.InchOfMercury,
+		PressureUnitPascal:          unit.Pascal,
+	}
+)
+
+// Speed is a speed field, the value is in the unit that is declared in the sentence
+type Speed struct {
+	Float64
+	Unit string
+}
+
+// NewSpeed creates a valid Speed
+func NewSpeed(v float64, unit string) Speed {
+	return Speed{Float64: NewFloat64(v), Unit: unit}
+}
+
+// NewInvalidSpeed creates an invalid Speed
+func NewInvalidSpeed(reason string) Speed {
+	return Speed{Float64: NewInvalidFloat64(reason)}
+}
+
+// GetSpeed returns the speed or an error if the value is invalid or the unit is unknown
+func (v Speed) GetSpeed() (unit.Speed, error) {
+	f, err := v.GetValue()
+	if err != nil {
+		return 0, err
+	}
+	if u, ok := speedUnits[v.Unit]; ok {
+		return unit.Speed(f) * u, nil
+	}
+	return 0, fmt.Errorf("unknown speed unit '%s'", v.Unit)
+}
+
+// Length is a length or depth field, the value is in the unit that is declared in the sentence
+type Length struct {
+	Float64
+	Unit string
+}
+
+// NewLength creates a valid Length
+func NewLength(v float64, unit string) Length {
+	return Length{Float64: NewFloat64(v), Unit: unit}
+}
+
+// NewInvalidLength creates an invalid Length
+func NewInvalidLength(reason string) Length {
+	return Length{Float64: NewInvalidFloat64(reason)}
+}
+
+// GetLength returns the length or an error if the value is invalid or the unit is unknown
+func (v Length) GetLength() (unit.Length, error) {
+	f, err := v.GetValue()
+	if err != nil {
+		return 0, err
+	}
+	if u, ok := lengthUnits[v.Unit]; ok {
+		return unit.Length(f) * u, nil
+	}
+	return 0, fmt.Errorf("unknown length unit '%s'", v.Unit)
+}
+
+// Temperature is a temperature field, the value is in the unit that is declared in the sentence
+type Temperature struct {
+	Float64
+	Unit string
+}
+
+// NewTemperature creates a valid Temperature
+func NewTemperature(v float64, unit string) Temperature {
+	return Temperature{Float64: NewFloat64(v), Unit: unit}
+}
+
+// NewInvalidTemperature creates an invalid Temperature
+func NewInvalidTemperature(reason string) Temperature {
+	return Temperature{Float64: NewInvalidFloat64(reason)}
+}
+
+// GetTemperature returns the temperature or an error if the value is invalid or the unit is unknown
+func (v Temperature) GetTemperature() (unit.Temperature, error) {
+	f, err := v.GetValue()
+	if err != nil {
+		return 0, err
+	}
+	switch v.Unit {
+	case TemperatureUnitCelsius:
+		return unit.FromCelsius(f), nil
+	case TemperatureUnitFahrenheit:
+		return unit.FromFahrenheit(f), nil
+	case TemperatureUnitKelvin:
+		return unit.FromKelvin(f), nil
+	}
+	return 0, fmt.Errorf("unknown temperature unit '%s'", v.Unit)
+}
+
+// Pressure is a pressure field, the value is in the unit that is declared in the sentence
+type Pressure struct {
+	Float64
+	Unit string
+}
+
+// NewPressure creates a valid Pressure
+func NewPressure(v float64, unit string) Pressure {
+	return Pressure{Float64: NewFloat64(v), Unit: unit}
+}
+
+// NewInvalidPressure creates an invalid Pressure
+func NewInvalidPressure(reason string) Pressure {
+	return Pressure{Float64: NewInvalidFloat64(reason)}
+}
+
+// GetPressure returns the pressure or an error if the value is invalid or the unit is unknown
+func (v Pressure) GetPressure() (unit.Pressure, error) {
+	f, err := v.GetValue()
+	if err != nil {
+		return 0, err
+	}
+	if u, ok := pressureUnits[v.Unit]; ok {
+		return unit.Pressure(f) * u, nil
+	}
+	return 0, fmt.Errorf("unknown pressure unit '%s'", v.Unit)
+}
+
+// unitNames returns the names of the units of a speed by unit character
+func (v Speed) unitNames() map[string]string {
+	return speedUnitNames
+}
+
+// MarshalJSON encodes the speed as the value and the name of the unit, an invalid speed as null
+func (v Speed) MarshalJSON() ([]byte, error) {
+	return marshalMeasurement(v.Float64, v.Unit, speedUnitNames)
+}
+
+// UnmarshalJSON decodes the value and the name of the unit of the speed, see MarshalJSON
+func (v *Speed) UnmarshalJSON(data []byte) error {
+	return unmarshalMeasurement(data, &v.Float64, &v.Unit, speedUnitNames)
+}
+
+// unitNames returns the names of the units of a length by unit character
+func (v Length) unitNames() map[string]string {
+	return lengthUnitNames
+}
+
+// MarshalJSON encodes the length as the value and the name of the unit, an invalid length as null
+func (v Length) MarshalJSON() ([]byte, error) {
+	return marshalMeasurement(v.Float64, v.Unit, lengthUnitNames)
+}
+
+// UnmarshalJSON decodes the value and the name of the unit of the length, see MarshalJSON
+func (v *Length) UnmarshalJSON(data []byte) error {
+	return unmarshalMeasurement(data, &v.Float64, &v.Unit, lengthUnitNames)
+}
+
+// unitNames returns the names of the units of a temperature by unit character
+func (v Temperature) unitNames() map[string]string {
+	return temperatureUnitNames
+}
+
+// MarshalJSON encodes the temperature as the value and the name of the unit, an invalid temperature as null
+func (v Temperature) MarshalJSON() ([]byte, error) {
+	return marshalMeasurement(v.Float64, v.Unit, temperatureUnitNames)
+}
+
+// UnmarshalJSON decodes the value and the name of the unit of the temperature, see MarshalJSON
+func (v *Temperature) UnmarshalJSON(data []byte) error {
+	return unmarshalMeasurement(data, &v.Float64, &v.Unit, temperatureUnitNames)
+}
+
+// unitNames returns the names of the units of a pressure by unit character
+func (v Pressure) unitNames() map[string]string {
+	return pressureUnitNames
+}
+
+// MarshalJSON encodes the pressure as the value and the name of the unit, an invalid pressure as null
+func (v Pressure) MarshalJSON() ([]byte, error) {
+	return marshalMeasurement(v.Float64, v.Unit, pressureUnitNames)
+}
+
+// UnmarshalJSON decodes the value and the name of the unit of the pressure, see MarshalJSON
+func (v *Pressure) UnmarshalJSON(data []byte) error {
+	return unmarshalMeasurement(data, &v.Float64, &v.Unit, pressureUnitNames)
+}
+
+type measurementJSON struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+func marshalMeasurement(v Float64, unit string, names map[string]string) ([]byte, error) {
+	if !v.Valid {
+		return []byte("null"), nil
+	}
+	name, ok := names[unit]
+	if !ok {
+		return nil, fmt.Errorf("nmea: unknown unit '%s'", unit)
+	}
+	return json.Marshal(measurementJSON{Value: v.Value, Unit: name})
+}
+
+func unmarshalMeasurement(data []byte, v *Float64, unit *string, names map[string]string) error {
+	var m *measurementJSON
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if m == nil {
+		*v, *unit = NewInvalidFloat64("value is null"), ""
+		return nil
+	}
+	char, err := unitChar(m.Unit, names)
+	if err != nil {
+		return err
+	}
+	*v, *unit = NewFloat64(m.Value), char
+	return nil
+}
+
+// unitChar returns the unit character of the name of the unit
+func unitChar(name string, names map[string]string) (string, error) {
+	for char, n := range names {
+		if n == name {
+			return char, nil
+		}
+	}
+	return "", fmt.Errorf("nmea: unknown unit '%s'", name)
+}
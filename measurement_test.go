@@ -0,0 +1,86 @@
+package nmea_test
+
+import (
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Measurement", func() {
+	Context("when getting the value in another unit", func() {
+		It("converts a speed", func() {
+			v, err := NewSpeed(10, SpeedUnitKnots).GetSpeed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.MetersPerSecond()).To(BeNumerically("~", 5.14444, 0.00001))
+			Expect(v.KilometersPerHour()).To(BeNumerically("~", 18.52, 0.0001))
+		})
+		It("converts a length", func() {
+			v, err := NewLength(1, LengthUnitFathoms).GetLength()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.Feet()).To(BeNumerically("~", 6, 0.00001))
+		})
+		It("converts a temperature", func() {
+			v, err := NewTemperature(AirTemperatureCelsius, TemperatureUnitCelsius).GetTemperature()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.Kelvin()).To(BeNumerically("~", AirTemperatureKelvin, 0.00001))
+			v, err = NewTemperature(212, TemperatureUnitFahrenheit).GetTemperature()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.Celsius()).To(BeNumerically("~", 100, 0.00001))
+		})
+		It("converts a pressure", func() {
+			v, err := NewPressure(PressureBar, PressureUnitBar).GetPressure()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.Pascals()).To(BeNumerically("~", PressurePascal, 0.00001))
+		})
+	})
+	Context("when the value can not be converted", func() {
+		It("returns an error for an invalid value", func() {
+			_, err := NewInvalidSpeed("empty").GetSpeed()
+			Expect(err).To(MatchError("empty"))
+		})
+		It("returns an error for an unknown unit", func() {
+			_, err := NewSpeed(1, "X").GetSpeed()
+			Expect(err).To(MatchError("unknown speed unit 'X'"))
+			_, err = NewLength(1, "").GetLength()
+			Expect(err).To(MatchError("unknown length unit ''"))
+			_, err = NewTemperature(1, "X").GetTemperature()
+			Expect(err).To(MatchError("unknown temperature unit 'X'"))
+			_, err = NewPressure(1, "X").GetPressure()
+			Expect(err).To(MatchError("unknown pressure unit 'X'"))
+		})
+	})
+	Context("when parsing a measurement", func() {
+		var p *Parser
+		BeforeEach(func() {
+			p = NewParser(BaseSentence{
+				Talker:      "II",
+				Type:        "XYZ",
+				Fields:      []string{"1.5", "N", "2.5", "X", "3.5", "", "4.5", ""},
+				Diagnostics: &Diagnostics{},
+			})
+		})
+		It("returns the value with the unit", func() {
+			Expect(p.Speed(0, 1, "speed", SpeedUnitKnots, SpeedUnitKilometersPerHour)).To(Equal(NewSpeed(1.5, SpeedUnitKnots)))
+			Expect(p.Length(0, -1, "depth", LengthUnitMeters)).To(Equal(NewLength(1.5, LengthUnitMeters)))
+			Expect(p.Diagnostics.FieldErrors).To(BeEmpty())
+		})
+		It("flags a unit that does not match", func() {
+			v := p.Speed(2, 3, "speed", SpeedUnitKnots)
+			Expect(v.Valid).To(BeFalse())
+			Expect(v.InvalidReason).To(Equal("unit 'X' is not one of [N]"))
+			Expect(v.Unit).To(Equal("X"))
+			Expect(p.Diagnostics.FieldErrors).To(ConsistOf(FieldError{Prefix: "IIXYZ", Index: 3, Context: "speed unit", Value: "unit 'X' is not one of [N]", Raw: "X"}))
+			Expect(p.Err()).ToNot(HaveOccurred())
+		})
+		It("assumes the unit of a field with only one unit when the unit is missing", func() {
+			Expect(p.Temperature(4, 5, "temperature", TemperatureUnitCelsius)).To(Equal(NewTemperature(3.5, TemperatureUnitCelsius)))
+			Expect(p.Diagnostics.FieldErrors).To(ConsistOf(FieldError{Prefix: "IIXYZ", Index: 5, Context: "temperature unit", Value: "missing unit"}))
+		})
+		It("flags a missing unit when there is more than one unit", func() {
+			v := p.Pressure(6, 7, "pressure", PressureUnitBar, PressureUnitPascal)
+			Expect(v.Valid).To(BeFalse())
+			Expect(v.InvalidReason).To(Equal("missing unit"))
+			Expect(p.Diagnostics.FieldErrors).To(HaveLen(1))
+		})
+	})
+})
@@ -1,25 +1,41 @@
 package nmea
 
 const (
-	// TypeMTK type for PMTK sentences
+	// TypeMTK is the schema type of PMTK sentences, the data type of a PMTK sentence is MTK
+	// followed by the packet type, e.g. MTK001
 	TypeMTK = "PMTK"
+	// ManufacturerMTK is the manufacturer mnemonic of MediaTek
+	ManufacturerMTK = "MTK"
 )
 
 // MTK is the Time, position, and fix related data of the receiver.
+// MTK embeds Proprietary, the BaseSentence is at MTK.Proprietary.BaseSentence
 type MTK struct {
-	BaseSentence
+	Proprietary
 	Cmd  Int64
 	Flag Int64
 }
 
 // newMTK constructor
 func newMTK(s BaseSentence) (MTK, error) {
+	proprietary, err := NewProprietary(s)
+	if err != nil {
+		return MTK{}, err
+	}
 	p := NewParser(s)
 	cmd := p.Int64(0, "command")
 	flag := p.Int64(1, "flag")
 	return MTK{
-		BaseSentence: s,
-		Cmd:          cmd,
-		Flag:         flag,
+		Proprietary: proprietary,
+		Cmd:         cmd,
+		Flag:        flag,
 	}, p.Err()
 }
+
+// Encode serializes the MTK sentence into NMEA 0183 text
+func (s MTK) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Int64(s.Cmd)
+	e.Int64(s.Flag)
+	return e.Encode()
+}
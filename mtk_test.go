@@ -86,6 +86,12 @@ var _ = Describe("MTK", func() {
 					"Flag": Equal(NewInt64(3)),
 				}))
 			})
+			It("is parsed as a proprietary sentence of MediaTek", func() {
+				Expect(parsed.Talker).To(Equal("P"))
+				Expect(parsed.DataType()).To(Equal("MTK001"))
+				Expect(parsed.Manufacturer).To(Equal(ManufacturerMTK))
+				Expect(parsed.SubID).To(Equal("001"))
+			})
 		})
 		Context("a sentence missing flag", func() {
 			BeforeEach(func() {
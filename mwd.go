@@ -26,8 +26,8 @@ type MWD struct {
 	BaseSentence
 	WindDirectionTrue          Float64
 	WindDirectionMagnetic      Float64
-	WindSpeedInKnots           Float64
-	WindSpeedInMetersPerSecond Float64
+	WindSpeedInKnots           Speed
+	WindSpeedInMetersPerSecond Speed
 }
 
 // newMWD constructor
@@ -38,8 +38,8 @@ func newMWD(s BaseSentence) (MWD, error) {
 		BaseSentence:               s,
 		WindDirectionTrue:          p.Float64(0, "WindDirectionTrue"),
 		WindDirectionMagnetic:      p.Float64(2, "WindDirectionMagnetic"),
-		WindSpeedInKnots:           p.Float64(4, "WindSpeedInKnots"),
-		WindSpeedInMetersPerSecond: p.Float64(6, "WindSpeedInMetersPerSecond"),
+		WindSpeedInKnots:           p.Speed(4, 5, "WindSpeedInKnots", SpeedUnitKnots),
+		WindSpeedInMetersPerSecond: p.Speed(6, 7, "WindSpeedInMetersPerSecond", SpeedUnitMetersPerSecond),
 	}
 	return m, p.Err()
 }
@@ -62,11 +62,24 @@ func (s MWD) GetMagneticWindDirection() (float64, error) {
 
 // GetWindSpeed retrieves wind speed from the sentence
 func (s MWD) GetWindSpeed() (float64, error) {
-	if v, err := s.WindSpeedInMetersPerSecond.GetValue(); err == nil {
-		return v, nil
-	}
-	if v, err := s.WindSpeedInKnots.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
+	for _, speed := range []Speed{s.WindSpeedInMetersPerSecond, s.WindSpeedInKnots} {
+		if v, err := speed.GetSpeed(); err == nil {
+			return v.MetersPerSecond(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the MWD sentence into NMEA 0183 text
+func (s MWD) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.WindDirectionTrue)
+	e.RawField("T")
+	e.Float64(s.WindDirectionMagnetic)
+	e.RawField("M")
+	e.Float64(s.WindSpeedInKnots.Float64)
+	e.RawField(SpeedUnitKnots)
+	e.Float64(s.WindSpeedInMetersPerSecond.Float64)
+	e.RawField(SpeedUnitMetersPerSecond)
+	return e.Encode()
+}
@@ -34,8 +34,8 @@ var _ = Describe("MWD", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
 					"WindDirectionTrue":          Equal(NewFloat64(351.1)),
 					"WindDirectionMagnetic":      Equal(NewFloat64(350.8)),
-					"WindSpeedInKnots":           Equal(NewFloat64(8.4)),
-					"WindSpeedInMetersPerSecond": Equal(NewFloat64(4.3)),
+					"WindSpeedInKnots":           Equal(NewSpeed(8.4, SpeedUnitKnots)),
+					"WindSpeedInMetersPerSecond": Equal(NewSpeed(4.3, SpeedUnitMetersPerSecond)),
 				}))
 			})
 		})
@@ -56,8 +56,8 @@ var _ = Describe("MWD", func() {
 			parsed = MWD{
 				WindDirectionTrue:          NewFloat64(TrueDirectionDegrees),
 				WindDirectionMagnetic:      NewFloat64(MagneticDirectionDegrees),
-				WindSpeedInKnots:           NewFloat64(SpeedOverGroundKnots),
-				WindSpeedInMetersPerSecond: NewFloat64(SpeedOverGroundMPS),
+				WindSpeedInKnots:           NewSpeed(SpeedOverGroundKnots, SpeedUnitKnots),
+				WindSpeedInMetersPerSecond: NewSpeed(SpeedOverGroundMPS, SpeedUnitMetersPerSecond),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -73,7 +73,7 @@ var _ = Describe("MWD", func() {
 		})
 		Context("when having a struct is missing wind speed in meters per second", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInMetersPerSecond = NewInvalidFloat64("")
+				parsed.WindSpeedInMetersPerSecond = NewInvalidSpeed("")
 			})
 			It("returns a valid true wind direction", func() {
 				Expect(parsed.GetTrueWindDirection()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -87,7 +87,7 @@ var _ = Describe("MWD", func() {
 		})
 		Context("when having a struct is missing wind speed in knots", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInKnots = NewInvalidFloat64("")
+				parsed.WindSpeedInKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid true wind direction", func() {
 				Expect(parsed.GetTrueWindDirection()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -40,11 +40,10 @@ const (
 // MWV - Wind Speed and Angle
 type MWV struct {
 	BaseSentence
-	Angle         Float64
-	Reference     String
-	WindSpeed     Float64
-	WindSpeedUnit String
-	Status        String
+	Angle     Float64
+	Reference String
+	WindSpeed Speed // The unit of the wind speed field is parsed into and encoded from WindSpeed.Unit
+	Status    String
 }
 
 // newMWV constructor
@@ -52,18 +51,24 @@ func newMWV(s BaseSentence) (MWV, error) {
 	p := NewParser(s)
 	p.AssertType(TypeMWV)
 	m := MWV{
-		BaseSentence:  s,
-		Angle:         p.Float64(0, "Angle"),
-		Reference:     p.EnumString(1, "Reference", ReferenceRelative, ReferenceTrue),
-		WindSpeed:     p.Float64(2, "WindSpeed"),
-		WindSpeedUnit: p.EnumString(3, "WindSpeedUnit", WindSpeedUnitKPH, WindSpeedUnitKnots, WindSpeedUnitMPS, WindSpeedUnitMPH),
-		Status:        p.EnumString(4, "Status", ValidMWV, InvalidMWV),
+		BaseSentence: s,
+		Angle:        p.Float64(0, "Angle"),
+		Reference:    p.EnumString(1, "Reference", ReferenceRelative, ReferenceTrue),
+		WindSpeed:    p.Speed(2, 3, "WindSpeed", WindSpeedUnitKPH, WindSpeedUnitKnots, WindSpeedUnitMPS, WindSpeedUnitMPH),
+		Status:       p.EnumString(4, "Status", ValidMWV, InvalidMWV),
 	}
 	return m, p.Err()
 }
 
-// GetTrueWindDirection retrieves the true wind direction from the sentence
+// GetTrueWindDirection retrieves the true wind angle from the sentence.
+//
+// Deprecated: the angle is relative to the bow and not to true north, use GetTrueWindAngle.
 func (s MWV) GetTrueWindDirection() (float64, error) {
+	return s.GetTrueWindAngle()
+}
+
+// GetTrueWindAngle retrieves the true wind angle relative to the bow from the sentence
+func (s MWV) GetTrueWindAngle() (float64, error) {
 	if s.Status.Value == ValidMWV && s.Reference.Value == ReferenceTrue {
 		if v, err := s.Angle.GetValue(); err == nil {
 			return (unit.Angle(v) * unit.Degree).Radians(), nil
@@ -84,15 +89,19 @@ func (s MWV) GetRelativeWindDirection() (float64, error) {
 
 // GetWindSpeed retrieves wind speed from the sentence
 func (s MWV) GetWindSpeed() (float64, error) {
-	if v, err := s.WindSpeed.GetValue(); err == nil && s.Status.Value == ValidMWV {
-		switch s.WindSpeedUnit.Value {
-		case WindSpeedUnitMPS:
-			return v, nil
-		case WindSpeedUnitKPH:
-			return (unit.Speed(v) * unit.KilometersPerHour).MetersPerSecond(), nil
-		case WindSpeedUnitKnots:
-			return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
-		}
+	if v, err := s.WindSpeed.GetSpeed(); err == nil && s.Status.Value == ValidMWV {
+		return v.MetersPerSecond(), nil
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the MWV sentence into NMEA 0183 text
+func (s MWV) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Angle)
+	e.StringField(s.Reference)
+	e.Float64(s.WindSpeed.Float64)
+	e.RawField(s.WindSpeed.Unit)
+	e.StringField(s.Status)
+	return e.Encode()
+}
@@ -32,11 +32,10 @@ var _ = Describe("MWV", func() {
 			})
 			It("equals a valid MWV struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Angle":         Equal(NewFloat64(117.5)),
-					"Reference":     Equal(NewString(ReferenceRelative)),
-					"WindSpeed":     Equal(NewFloat64(4.6)),
-					"WindSpeedUnit": Equal(NewString(WindSpeedUnitKnots)),
-					"Status":        Equal(NewString(ValidMWV)),
+					"Angle":     Equal(NewFloat64(117.5)),
+					"Reference": Equal(NewString(ReferenceRelative)),
+					"WindSpeed": Equal(NewSpeed(4.6, WindSpeedUnitKnots)),
+					"Status":    Equal(NewString(ValidMWV)),
 				}))
 			})
 		})
@@ -49,11 +48,10 @@ var _ = Describe("MWV", func() {
 			})
 			It("equals a valid MWV struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Angle":         Equal(NewFloat64(117.5)),
-					"Reference":     Equal(NewInvalidString("not a valid option")),
-					"WindSpeed":     Equal(NewFloat64(4.6)),
-					"WindSpeedUnit": Equal(NewString(WindSpeedUnitKnots)),
-					"Status":        Equal(NewString(ValidMWV)),
+					"Angle":     Equal(NewFloat64(117.5)),
+					"Reference": Equal(NewInvalidString("not a valid option")),
+					"WindSpeed": Equal(NewSpeed(4.6, WindSpeedUnitKnots)),
+					"Status":    Equal(NewString(ValidMWV)),
 				}))
 			})
 		})
@@ -64,13 +62,12 @@ var _ = Describe("MWV", func() {
 			It("returns no errors", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
-			It("equals a valid MWV struct", func() {
+			It("equals a MWV struct with an invalid wind speed", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Angle":         Equal(NewFloat64(117.5)),
-					"Reference":     Equal(NewString(ReferenceRelative)),
-					"WindSpeed":     Equal(NewFloat64(4.6)),
-					"WindSpeedUnit": Equal(NewInvalidString("not a valid option")),
-					"Status":        Equal(NewString(ValidMWV)),
+					"Angle":     Equal(NewFloat64(117.5)),
+					"Reference": Equal(NewString(ReferenceRelative)),
+					"WindSpeed": Equal(Speed{Float64: NewInvalidFloat64("unit 'L' is not one of [K N M S]"), Unit: "L"}),
+					"Status":    Equal(NewString(ValidMWV)),
 				}))
 			})
 		})
@@ -83,11 +80,10 @@ var _ = Describe("MWV", func() {
 			})
 			It("equals a valid MWV struct", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
-					"Angle":         Equal(NewFloat64(117.5)),
-					"Reference":     Equal(NewString(ReferenceRelative)),
-					"WindSpeed":     Equal(NewFloat64(4.6)),
-					"WindSpeedUnit": Equal(NewString(WindSpeedUnitKnots)),
-					"Status":        Equal(NewString(InvalidMWV)),
+					"Angle":     Equal(NewFloat64(117.5)),
+					"Reference": Equal(NewString(ReferenceRelative)),
+					"WindSpeed": Equal(NewSpeed(4.6, WindSpeedUnitKnots)),
+					"Status":    Equal(NewString(InvalidMWV)),
 				}))
 			})
 		})
@@ -106,11 +102,10 @@ var _ = Describe("MWV", func() {
 	Describe("Getting data from a MWV struct", func() {
 		BeforeEach(func() {
 			parsed = MWV{
-				Angle:         NewFloat64(RelativeDirectionDegrees),
-				Reference:     NewString(ReferenceRelative),
-				WindSpeed:     NewFloat64(SpeedOverGroundMPS),
-				WindSpeedUnit: NewString(WindSpeedUnitMPS),
-				Status:        NewString(ValidMWV),
+				Angle:     NewFloat64(RelativeDirectionDegrees),
+				Reference: NewString(ReferenceRelative),
+				WindSpeed: NewSpeed(SpeedOverGroundMPS, WindSpeedUnitMPS),
+				Status:    NewString(ValidMWV),
 			}
 		})
 		Context("when having a struct with reference set to relative", func() {
@@ -137,11 +132,13 @@ var _ = Describe("MWV", func() {
 			It("returns a valid true wind direction", func() {
 				Expect(parsed.GetTrueWindDirection()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
 			})
+			It("returns a valid true wind angle", func() {
+				Expect(parsed.GetTrueWindAngle()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
+			})
 		})
 		Context("when having a struct with wind speed in kmh", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeed = NewFloat64(SpeedOverGroundKPH)
-				parsed.WindSpeedUnit = NewString(WindSpeedUnitKPH)
+				parsed.WindSpeed = NewSpeed(SpeedOverGroundKPH, WindSpeedUnitKPH)
 			})
 			It("returns a valid wind speed", func() {
 				Expect(parsed.GetWindSpeed()).To(BeNumerically("~", SpeedOverGroundMPS, 0.00001))
@@ -149,8 +146,7 @@ var _ = Describe("MWV", func() {
 		})
 		Context("when having a struct with wind speed in knots", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeed = NewFloat64(SpeedOverGroundKnots)
-				parsed.WindSpeedUnit = NewString(WindSpeedUnitKnots)
+				parsed.WindSpeed = NewSpeed(SpeedOverGroundKnots, WindSpeedUnitKnots)
 			})
 			It("returns a valid wind speed", func() {
 				Expect(parsed.GetWindSpeed()).To(BeNumerically("~", SpeedOverGroundMPS, 0.00001))
@@ -158,8 +154,7 @@ var _ = Describe("MWV", func() {
 		})
 		Context("when having a struct with an invalid wind speed unit", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeed = NewFloat64(SpeedOverGroundKnots)
-				parsed.WindSpeedUnit = NewString("A")
+				parsed.WindSpeed = NewSpeed(SpeedOverGroundKnots, "A")
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetWindSpeed()
@@ -201,4 +196,16 @@ var _ = Describe("MWV", func() {
 			})
 		})
 	})
+	Describe("Encoding a MWV struct", func() {
+		It("writes the unit of the wind speed", func() {
+			mwv := MWV{
+				BaseSentence: BaseSentence{Talker: "WI", Type: TypeMWV},
+				Angle:        NewFloat64(117.5),
+				Reference:    NewString(ReferenceRelative),
+				WindSpeed:    NewSpeed(4.6, WindSpeedUnitMPS),
+				Status:       NewString(ValidMWV),
+			}
+			Expect(mwv.Encode()).To(Equal("$WIMWV,117.5,R,4.6,M,A*20"))
+		})
+	})
 })
@@ -0,0 +1,97 @@
+package nmea
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTalkerNotAllowed is returned when the talker of a sentence is denied or not in the allowed talkers
+	ErrTalkerNotAllowed = errors.New("nmea: talker not allowed")
+	// ErrSentenceTooLong is returned when a sentence is longer than the maximum length
+	ErrSentenceTooLong = errors.New("nmea: sentence exceeds the maximum length")
+	// ErrUnknownOption is returned when a string option is not one of the supported options
+	ErrUnknownOption = errors.New("nmea: unknown parse option")
+)
+
+// ParseConfig contains the options of the parser, the zero value parses like Parse without options
+type ParseConfig struct {
+	// AllowEmptyChecksum accepts sentences and tag blocks without a checksum
+	AllowEmptyChecksum bool
+	// AllowChecksumMismatch accepts sentences and tag blocks with an invalid checksum
+	AllowChecksumMismatch bool
+	// CollectFieldErrors collects all field errors in the Diagnostics of the sentence
+	CollectFieldErrors bool
+	// StrictFieldCount rejects sentences with less or more fields than the parser of the sentence
+	// uses, optional fields at the end of a sentence may be missing. By default the missing fields
+	// are returned as invalid values and extra fields are ignored.
+	StrictFieldCount bool
+	// AllowedTalkers are the only talkers that are accepted, all talkers are accepted when it is empty
+	AllowedTalkers []string
+	// DeniedTalkers are the talkers that are rejected
+	DeniedTalkers []string
+	// MaxLength is the maximum number of characters of a sentence excluding the tag block,
+	// there is no maximum when it is 0 or less. NMEA 0183 specifies a maximum of 82 characters.
+	MaxLength int
+	// CaseInsensitivePrefix accepts prefixes in lower or mixed case, e.g. $gprmc
+	CaseInsensitivePrefix bool
+	// UnknownPassthrough returns sentences without a parser as Unknown instead of an error
+	UnknownPassthrough bool
+	// Date contains the interpretation of the dates of the sentences, e.g. by RMC.GetTimestamp
+	// and ZDA.GetTimestamp
+	Date DateConfig
+	// Registry is used instead of the default registry when it is not nil
+	Registry *Registry
+	// AISAssembler assembles the fragments of multi-fragment VDM and VDO messages, the last
+	// fragment of a message contains the packet of the whole message. A package level assembler
+	// that keeps the fragments apart per tag block source, talker and channel is used when it is nil.
+	AISAssembler *AISAssembler
+}
+
+// ParseWithConfig parses the given string into the correct sentence type using the given config.
+func ParseWithConfig(raw string, config ParseConfig) (Sentence, error) {
+	s, err := parseSentence(raw, config)
+	if err != nil {
+		return nil, err
+	}
+	return parseBaseSentence(s, config)
+}
+
+// parseOptions converts the string options of Parse into a ParseConfig, ErrUnknownOption is
+// returned for an option that is not supported
+func parseOptions(options []string) (ParseConfig, error) {
+	var config ParseConfig
+	for _, option := range options {
+		switch option {
+		case "AllowEmptyChecksum":
+			config.AllowEmptyChecksum = true
+		case "AllowChecksumMismatch":
+			config.AllowChecksumMismatch = true
+		case "CollectFieldErrors":
+			config.CollectFieldErrors = true
+		case "UnknownPassthrough":
+			config.UnknownPassthrough = true
+		default:
+			return ParseConfig{}, fmt.Errorf("%w '%s'", ErrUnknownOption, option)
+		}
+	}
+	return config, nil
+}
+
+// talkerAllowed checks the talker against the allowed and denied talkers
+func (c ParseConfig) talkerAllowed(talker string) error {
+	for _, t := range c.DeniedTalkers {
+		if t == talker {
+			return fmt.Errorf("%w [%s]", ErrTalkerNotAllowed, talker)
+		}
+	}
+	if len(c.AllowedTalkers) == 0 {
+		return nil
+	}
+	for _, t := range c.AllowedTalkers {
+		if t == talker {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w [%s]", ErrTalkerNotAllowed, talker)
+}
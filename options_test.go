@@ -0,0 +1,217 @@
+package nmea_test
+
+import (
+	"errors"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func withChecksum(fields string) string {
+	return "$" + fields + "*" + Checksum(fields)
+}
+
+var _ = Describe("ParseConfig", func() {
+	var (
+		raw    string
+		config ParseConfig
+		result Sentence
+		err    error
+	)
+	BeforeEach(func() {
+		config = ParseConfig{}
+	})
+	JustBeforeEach(func() {
+		result, err = ParseWithConfig(raw, config)
+	})
+	Context("with the zero value", func() {
+		BeforeEach(func() {
+			raw = "$GPHDT,123.456,T*32"
+		})
+		It("parses like Parse without options", func() {
+			expected, _ := Parse(raw)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		})
+	})
+	Context("with a checksum mismatch", func() {
+		BeforeEach(func() {
+			raw = "$GPHDT,123.456,T*FF"
+		})
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ErrChecksumMismatch))
+		})
+		When("checksum mismatches are allowed", func() {
+			BeforeEach(func() {
+				config.AllowChecksumMismatch = true
+			})
+			It("returns the sentence", func() {
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+	Context("with missing fields", func() {
+		BeforeEach(func() {
+			raw = withChecksum("GPHDT,123.456")
+		})
+		It("returns the missing fields as invalid values", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.(HDT).True).To(BeFalse())
+		})
+		When("the field count is strict", func() {
+			BeforeEach(func() {
+				config.StrictFieldCount = true
+			})
+			It("returns an error", func() {
+				Expect(err).To(MatchError("nmea: GPHDT invalid number of fields: expected at least 2 fields, got 1"))
+			})
+		})
+	})
+	Context("with extra fields", func() {
+		BeforeEach(func() {
+			raw = withChecksum("GPHDT,123.456,T,X")
+		})
+		It("ignores the extra fields", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+		When("the field count is strict", func() {
+			BeforeEach(func() {
+				config.StrictFieldCount = true
+			})
+			It("returns an error", func() {
+				Expect(err).To(MatchError("nmea: GPHDT invalid number of fields: expected at most 2 fields, got 3"))
+			})
+		})
+	})
+	Context("with a strict field count and without optional fields", func() {
+		BeforeEach(func() {
+			raw = "$GNRMC,220516,A,5133.82,N,00042.24,W,173.8,231.8,130694,004.2,W*6E"
+			config.StrictFieldCount = true
+		})
+		It("returns the sentence", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	Context("with a strict field count and all fields", func() {
+		BeforeEach(func() {
+			raw = "$GPHDT,123.456,T*32"
+			config.StrictFieldCount = true
+		})
+		It("returns the sentence", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	Context("with allowed talkers", func() {
+		BeforeEach(func() {
+			raw = "$GPHDT,123.456,T*32"
+			config.AllowedTalkers = []string{"HE", "GN"}
+		})
+		It("rejects other talkers", func() {
+			Expect(err).To(MatchError("nmea: talker not allowed [GP]"))
+			Expect(errors.Is(err, ErrTalkerNotAllowed)).To(BeTrue())
+		})
+	})
+	Context("with denied talkers", func() {
+		BeforeEach(func() {
+			raw = "$GPHDT,123.456,T*32"
+			config.DeniedTalkers = []string{"GP"}
+		})
+		It("rejects the denied talkers", func() {
+			Expect(errors.Is(err, ErrTalkerNotAllowed)).To(BeTrue())
+		})
+	})
+	Context("with a maximum length", func() {
+		BeforeEach(func() {
+			raw = "\\s:Satellite_1,c:1553390539*0E\\$GPHDT,123.456,T*32"
+			config.MaxLength = 19
+		})
+		It("does not count the tag block", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+		When("the sentence is too long", func() {
+			BeforeEach(func() {
+				config.MaxLength = 18
+			})
+			It("returns an error", func() {
+				Expect(err).To(MatchError("nmea: sentence exceeds the maximum length [19 > 18]"))
+				Expect(errors.Is(err, ErrSentenceTooLong)).To(BeTrue())
+			})
+		})
+	})
+	Context("with a lower case prefix", func() {
+		BeforeEach(func() {
+			raw = withChecksum("gphdt,123.456,T")
+		})
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ErrUnsupportedPrefix))
+		})
+		When("prefixes are case insensitive", func() {
+			BeforeEach(func() {
+				config.CaseInsensitivePrefix = true
+			})
+			It("returns the sentence", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Prefix()).To(Equal("GPHDT"))
+			})
+		})
+	})
+	Context("with an unknown sentence", func() {
+		BeforeEach(func() {
+			raw = "$PSTIS,*61"
+		})
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ErrUnsupportedPrefix))
+		})
+		When("unknown sentences are passed through", func() {
+			BeforeEach(func() {
+				config.UnknownPassthrough = true
+			})
+			It("returns an unknown sentence", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(Equal(Unknown{BaseSentence: BaseSentence{Talker: "P", Type: "STIS", Fields: []string{""}, Checksum: "61", Raw: "$PSTIS,*61"}}))
+			})
+		})
+	})
+	Context("with a registry for a single call", func() {
+		BeforeEach(func() {
+			raw = "$PSTIS,*61"
+			config.Registry = DefaultRegistry().Clone()
+			Expect(config.Registry.Register(ParserKey{Type: "STIS"}, func(s BaseSentence) (Sentence, error) {
+				return s, nil
+			})).To(Succeed())
+		})
+		It("uses the registry", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.DataType()).To(Equal("STIS"))
+		})
+		It("does not change the default registry", func() {
+			_, err := Parse(raw)
+			Expect(err).To(MatchError(ErrUnsupportedPrefix))
+		})
+	})
+	Context("with an AIS assembler", func() {
+		BeforeEach(func() {
+			raw = "!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11"
+			config.AISAssembler = NewAISAssembler(DefaultAISFragmentTimeout)
+		})
+		It("assembles the fragments with the assembler", func() {
+			Expect(err).ToNot(HaveOccurred())
+			result, err = ParseWithConfig("!AIVDM,2,2,1,B,Q0C@00000000000,2*44", config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.(VDMVDO).Packet).ToNot(BeNil())
+			Expect(config.AISAssembler.Stats().Assembled).To(Equal(uint64(1)))
+		})
+	})
+	Context("when the string options are used", func() {
+		It("sets the options of the config", func() {
+			_, err := Parse("$GPHDT,123.456,T*FF", "AllowChecksumMismatch")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("returns an error for an unknown option", func() {
+			_, err := Parse("$GPHDT,123.456,T*32", "AllowChecksumMissmatch")
+			Expect(err).To(MatchError("nmea: unknown parse option 'AllowChecksumMissmatch'"))
+			Expect(errors.Is(err, ErrUnknownOption)).To(BeTrue())
+		})
+	})
+})
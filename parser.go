@@ -1,6 +1,7 @@
 package nmea
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -8,7 +9,8 @@ import (
 // sentence fields
 type Parser struct {
 	BaseSentence
-	err error
+	err        error
+	usedFields int // The number of fields that are accessed, including the fields that are missing
 }
 
 // NewParser constructor
@@ -23,27 +25,87 @@ func (p *Parser) AssertType(typ string) {
 	}
 }
 
-// Err returns the first error encountered during the parser's usage.
+// Err returns the first error encountered during the parser's usage. When the sentence
+// was parsed with the StrictFieldCount option an error is returned when fields are
+// accessed that are not present in the sentence or when the sentence has fields that
+// are not accessed.
 func (p *Parser) Err() error {
+	if p.err == nil && p.strictFieldCount && p.usedFields > len(p.Fields) {
+		p.SetErr("number of fields", fmt.Sprintf("expected at least %d fields, got %d", p.usedFields, len(p.Fields)))
+	}
+	if p.err == nil && p.strictFieldCount && p.usedFields < len(p.Fields) {
+		p.SetErr("number of fields", fmt.Sprintf("expected at most %d fields, got %d", p.usedFields, len(p.Fields)))
+	}
 	return p.err
 }
 
-// SetErr assigns an error. Calling this method has no
-// effect if there is already an error.
+// SetErr assigns a FieldError that is not about a single field. Calling this
+// method has no effect if there is already an error, in diagnostic mode all
+// errors are added to the Diagnostics.
 func (p *Parser) SetErr(context, value string) {
+	p.setErr(p.fieldError(-1, context, value))
+}
+
+// SetFieldErr assigns a FieldError for the field at the specified index. Calling
+// this method has no effect if there is already an error, in diagnostic mode all
+// errors are added to the Diagnostics.
+func (p *Parser) SetFieldErr(i int, context, value string) {
+	p.setErr(p.fieldError(i, context, value))
+}
+
+func (p *Parser) setErr(err FieldError) {
+	if p.Diagnostics != nil {
+		p.Diagnostics.FieldErrors = append(p.Diagnostics.FieldErrors, err)
+	}
 	if p.err == nil {
-		p.err = fmt.Errorf("nmea: %s invalid %s: %s", p.Prefix(), context, value)
+		p.err = &err
+	}
+}
+
+func (p *Parser) fieldError(i int, context, value string) FieldError {
+	err := FieldError{Prefix: p.Prefix(), Index: i, Context: context, Value: value}
+	if i >= 0 && i < len(p.Fields) {
+		err.Raw = p.Fields[i]
+	}
+	return err
+}
+
+// report adds a FieldError for the field at the specified index to the Diagnostics,
+// it has no effect when the parser is not in diagnostic mode. Empty fields are not
+// reported because NMEA 0183 allows null fields.
+func (p *Parser) report(i int, context, reason string) {
+	if p.Diagnostics == nil {
+		return
+	}
+	err := p.fieldError(i, context, reason)
+	if err.Raw == "" && i >= 0 && i < len(p.Fields) {
+		return
 	}
+	p.Diagnostics.FieldErrors = append(p.Diagnostics.FieldErrors, err)
 }
 
 // String returns the field value at the specified index.
 func (p *Parser) String(i int, context string) String {
+	if i >= p.usedFields {
+		p.usedFields = i + 1
+	}
 	if i < 0 || i >= len(p.Fields) {
+		p.report(i, context, "index out of range")
 		return NewInvalidString("index out of range")
 	}
 	return NewString(p.Fields[i])
 }
 
+// OptionalString returns the field value at the specified index like String, a missing field
+// is not counted as a used field because the field is optional, e.g. a field that was added in a
+// later version of NMEA 0183.
+func (p *Parser) OptionalString(i int, context string) String {
+	if i < 0 || i >= len(p.Fields) {
+		return NewInvalidString("index out of range")
+	}
+	return p.String(i, context)
+}
+
 // ListString returns a list of all fields from the given start index.
 // An error occurs if there is no fields after the given start index.
 func (p *Parser) ListString(from int, context string) StringList {
@@ -69,6 +131,7 @@ func (p *Parser) EnumString(i int, context string, options ...string) String {
 			return s
 		}
 	}
+	p.report(i, context, "not a valid option")
 	return NewInvalidString("not a valid option")
 }
 
@@ -81,6 +144,7 @@ func (p *Parser) EnumChars(i int, context string, options ...string) StringList
 		return NewInvalidStringList(s.InvalidReason)
 	}
 	result := make([]String, 0)
+	reported := false
 	for _, r := range s.Value {
 		rs := string(r)
 		found := false
@@ -92,6 +156,10 @@ func (p *Parser) EnumChars(i int, context string, options ...string) StringList
 			}
 		}
 		if !found {
+			if !reported {
+				p.report(i, context, "not a valid option")
+				reported = true
+			}
 			result = append(result, NewInvalidString("not a valid option"))
 		}
 	}
@@ -105,7 +173,11 @@ func (p *Parser) Int64(i int, context string) Int64 {
 	if !s.Valid {
 		return NewInvalidInt64(s.InvalidReason)
 	}
-	return ParseInt64(s.Value)
+	v := ParseInt64(s.Value)
+	if !v.Valid {
+		p.report(i, context, v.InvalidReason)
+	}
+	return v
 }
 
 // Float64 returns the float64 value at the specified index.
@@ -115,7 +187,72 @@ func (p *Parser) Float64(i int, context string) Float64 {
 	if !s.Valid {
 		return NewInvalidFloat64(s.InvalidReason)
 	}
-	return ParseFloat64(s.Value)
+	v := ParseFloat64(s.Value)
+	if !v.Valid {
+		p.report(i, context, v.InvalidReason)
+	}
+	return v
+}
+
+// Speed returns the speed value at the specified index with the unit of the field at the unit
+// index, see measurement.
+func (p *Parser) Speed(i, unitIndex int, context string, units ...string) Speed {
+	v, u := p.measurement(i, unitIndex, context, units)
+	return Speed{Float64: v, Unit: u}
+}
+
+// Length returns the length value at the specified index with the unit of the field at the unit
+// index, see measurement.
+func (p *Parser) Length(i, unitIndex int, context string, units ...string) Length {
+	v, u := p.measurement(i, unitIndex, context, units)
+	return Length{Float64: v, Unit: u}
+}
+
+// Temperature returns the temperature value at the specified index with the unit of the field at
+// the unit index, see measurement.
+func (p *Parser) Temperature(i, unitIndex int, context string, units ...string) Temperature {
+	v, u := p.measurement(i, unitIndex, context, units)
+	return Temperature{Float64: v, Unit: u}
+}
+
+// Pressure returns the pressure value at the specified index with the unit of the field at the
+// unit index, see measurement.
+func (p *Parser) Pressure(i, unitIndex int, context string, units ...string) Pressure {
+	v, u := p.measurement(i, unitIndex, context, units)
+	return Pressure{Float64: v, Unit: u}
+}
+
+// measurement returns the float64 value at the specified index and the unit of the field at the
+// unit index, the unit has to be one of the units. The first unit is used for sentences without a
+// unit field, the unit index is -1 for these sentences. A value with a unit that is not one of the
+// units is invalid. A value without a unit is invalid, unless there is only one unit. Both are
+// reported in diagnostic mode.
+func (p *Parser) measurement(i, unitIndex int, context string, units []string) (Float64, string) {
+	v := p.Float64(i, context)
+	if unitIndex < 0 {
+		return v, units[0]
+	}
+	u := p.String(unitIndex, context+" unit")
+	if !v.Valid {
+		return v, u.Value
+	}
+	if u.Value == "" {
+		if p.Diagnostics != nil {
+			p.Diagnostics.FieldErrors = append(p.Diagnostics.FieldErrors, p.fieldError(unitIndex, context+" unit", "missing unit"))
+		}
+		if len(units) == 1 {
+			return v, units[0]
+		}
+		return NewInvalidFloat64("missing unit"), ""
+	}
+	for _, unit := range units {
+		if u.Value == unit {
+			return v, u.Value
+		}
+	}
+	reason := fmt.Sprintf("unit '%s' is not one of %v", u.Value, units)
+	p.report(unitIndex, context+" unit", reason)
+	return NewInvalidFloat64(reason), u.Value
 }
 
 // Time returns the Time value at the specified index.
@@ -125,7 +262,11 @@ func (p *Parser) Time(i int, context string) Time {
 	if !s.Valid {
 		return NewInvalidTime(s.InvalidReason)
 	}
-	return ParseTime(s.Value)
+	v := ParseTime(s.Value)
+	if !v.Valid {
+		p.report(i, context, v.InvalidReason)
+	}
+	return v
 }
 
 // Date returns the Date value at the specified index.
@@ -135,7 +276,11 @@ func (p *Parser) Date(i int, context string) Date {
 	if !s.Valid {
 		return NewInvalidDate(s.InvalidReason)
 	}
-	return ParseDate(s.Value)
+	v := ParseDate(s.Value)
+	if !v.Valid {
+		p.report(i, context, v.InvalidReason)
+	}
+	return v
 }
 
 // LatLong returns the coordinate value of the specified fields.
@@ -148,8 +293,10 @@ func (p *Parser) LatLong(i, j int, context string) Float64 {
 	if !b.Valid {
 		return NewInvalidFloat64(b.InvalidReason)
 	}
-	s := fmt.Sprintf("%s %s", a.Value, b.Value)
-	v := ParseLatLong(s)
+	v := parseLatLong(a.Value, b.Value)
+	if !v.Valid && a.Value != "" && b.Value != "" {
+		p.report(i, context, v.InvalidReason)
+	}
 
 	return v
 }
@@ -160,25 +307,32 @@ func (p *Parser) SixBitASCIIArmour(i int, fillBits int, context string) []byte {
 		return nil
 	}
 	if fillBits < 0 || fillBits >= 6 {
-		p.SetErr(context, "fill bits")
+		p.SetFieldErr(i, context, "fill bits")
 		return nil
 	}
 
-	payload := []byte(p.String(i, "encoded payload").Value)
-	numBits := len(payload)*6 - fillBits
+	result, err := decodeSixBitASCIIArmour(p.String(i, "encoded payload").Value, fillBits)
+	if err != nil {
+		p.SetFieldErr(i, context, err.Error())
+		return nil
+	}
+	return result
+}
 
+// decodeSixBitASCIIArmour decodes the 6-bit ascii armoured payload into bits, one bit per byte
+func decodeSixBitASCIIArmour(payload string, fillBits int) ([]byte, error) {
+	numBits := len(payload)*6 - fillBits
 	if numBits < 0 {
-		p.SetErr(context, "num bits")
-		return nil
+		return nil, errors.New("num bits")
 	}
 
 	result := make([]byte, numBits)
 	resultIndex := 0
 
-	for _, v := range payload {
+	for j := 0; j < len(payload); j++ {
+		v := payload[j]
 		if v < 48 || v >= 120 {
-			p.SetErr(context, "data byte")
-			return nil
+			return nil, errors.New("data byte")
 		}
 
 		d := v - 48
@@ -192,5 +346,5 @@ func (p *Parser) SixBitASCIIArmour(i int, fillBits int, context string) []byte {
 		}
 	}
 
-	return result
+	return result, nil
 }
@@ -35,6 +35,23 @@ var _ = Describe("Parser", func() {
 			It("returns the error", func() {
 				p.SetErr("testing", "error")
 				Expect(p.Err()).To(MatchError("nmea: GNRMC invalid testing: error"))
+				Expect(p.Err()).To(MatchError(ErrInvalidField))
+				Expect(p.Err()).To(Equal(&FieldError{Prefix: "GNRMC", Index: -1, Context: "testing", Value: "error"}))
+			})
+		})
+		Context("when setting an error for a field", func() {
+			BeforeEach(func() {
+				sentence = "$GNRMC,143909.00,A,5107.0020216,N,11402.3294835,W,0.036,348.3,210307,0.0,E,A*31"
+			})
+			It("returns the error with the index and the raw value of the field", func() {
+				p.SetFieldErr(1, "validity", "not a valid option")
+				Expect(p.Err()).To(MatchError("nmea: GNRMC invalid validity: not a valid option"))
+				Expect(p.Err()).To(Equal(&FieldError{Prefix: "GNRMC", Index: 1, Context: "validity", Value: "not a valid option", Raw: "A"}))
+			})
+			It("keeps the first error", func() {
+				p.SetErr("testing", "error")
+				p.SetFieldErr(1, "validity", "not a valid option")
+				Expect(p.Err()).To(MatchError("nmea: GNRMC invalid testing: error"))
 			})
 		})
 		Context("when asserting it is a RMC sentence", func() {
@@ -299,6 +316,57 @@ var _ = Describe("Parser", func() {
 			})
 		})
 	})
+	Describe("Testing diagnostic mode", func() {
+		var (
+			raw    string
+			result Sentence
+			err    error
+		)
+		JustBeforeEach(func() {
+			result, err = Parse(raw, "CollectFieldErrors")
+		})
+		Context("when parsing a sentence with multiple invalid fields", func() {
+			BeforeEach(func() {
+				fields := "GPGGA,0342x5.077,3356.4650,S,15124.5567,E,9,three,9.7,-25.0,M,21.0,M,,0000"
+				raw = "$" + fields + "*" + Checksum(fields)
+			})
+			It("collects all field errors", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(FieldErrors(result)).To(Equal([]FieldError{
+					{Prefix: "GPGGA", Index: 0, Context: "time", Value: "parse time: expected hhmmss.ss format, got '0342x5.077'", Raw: "0342x5.077"},
+					{Prefix: "GPGGA", Index: 5, Context: "fix quality", Value: "not a valid option", Raw: "9"},
+					{Prefix: "GPGGA", Index: 6, Context: "number of satellites", Value: "strconv.ParseInt: parsing \"three\": invalid syntax", Raw: "three"},
+				}))
+			})
+		})
+		Context("when parsing a sentence with empty fields", func() {
+			BeforeEach(func() {
+				raw = "$GNRMC,143909.00,A,5107.0020216,N,11402.3294835,W,0.036,348.3,210307,,,A*5A"
+			})
+			It("does not report the empty fields", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(FieldErrors(result)).To(BeEmpty())
+			})
+		})
+		Context("when parsing a sentence without the option", func() {
+			It("does not collect the field errors", func() {
+				fields := "GPGGA,0342x5.077,3356.4650,S,15124.5567,E,9,three,9.7,-25.0,M,21.0,M,,0000"
+				result, err := Parse("$" + fields + "*" + Checksum(fields))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(FieldErrors(result)).To(BeNil())
+			})
+		})
+		Context("when setting multiple errors", func() {
+			It("keeps the first error and reports all errors", func() {
+				p := NewParser(BaseSentence{Talker: "GP", Type: "RMC", Fields: []string{"a", "b"}, Diagnostics: &Diagnostics{}})
+				p.SetFieldErr(0, "first", "error")
+				p.SetFieldErr(1, "second", "error")
+				Expect(p.Err()).To(MatchError("nmea: GPRMC invalid first: error"))
+				Expect(p.Diagnostics.FieldErrors).To(HaveLen(2))
+				Expect(p.Diagnostics.FieldErrors[1].Raw).To(Equal("b"))
+			})
+		})
+	})
 })
 
 // import (
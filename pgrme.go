@@ -1,16 +1,21 @@
 package nmea
 
+import "fmt"
+
 const (
 	// TypePGRME type for PGRME sentences
 	TypePGRME = "GRME"
+	// ManufacturerGarmin is the manufacturer mnemonic of Garmin
+	ManufacturerGarmin = "GRM"
 	// ErrorUnit must be meters (M)
 	ErrorUnit = "M"
 )
 
 // PGRME is Estimated Position Error (Garmin proprietary sentence)
 // http://aprs.gids.nl/nmea/#rme
+// PGRME embeds Proprietary, the BaseSentence is at PGRME.Proprietary.BaseSentence
 type PGRME struct {
-	BaseSentence
+	Proprietary
 	Horizontal Float64 // Estimated horizontal position error (HPE) in metres
 	Vertical   Float64 // Estimated vertical position error (VPE) in metres
 	Spherical  Float64 // Overall spherical equivalent position error in meters
@@ -18,6 +23,10 @@ type PGRME struct {
 
 // newPGRME constructor
 func newPGRME(s BaseSentence) (PGRME, error) {
+	proprietary, err := NewProprietary(s)
+	if err != nil {
+		return PGRME{}, err
+	}
 	p := NewParser(s)
 	p.AssertType(TypePGRME)
 
@@ -31,9 +40,37 @@ func newPGRME(s BaseSentence) (PGRME, error) {
 	_ = p.EnumString(5, "spherical error unit", ErrorUnit)
 
 	return PGRME{
-		BaseSentence: s,
-		Horizontal:   horizontal,
-		Vertical:     vertial,
-		Spherical:    spherical,
+		Proprietary: proprietary,
+		Horizontal:  horizontal,
+		Vertical:    vertial,
+		Spherical:   spherical,
 	}, p.Err()
 }
+
+// GetHorizontalAccuracy retrieves the estimated horizontal position error in meters from the sentence
+func (s PGRME) GetHorizontalAccuracy() (float64, error) {
+	if v, err := s.Horizontal.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// GetVerticalAccuracy retrieves the estimated vertical position error in meters from the sentence
+func (s PGRME) GetVerticalAccuracy() (float64, error) {
+	if v, err := s.Vertical.GetValue(); err == nil {
+		return v, nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the PGRME sentence into NMEA 0183 text
+func (s PGRME) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Horizontal)
+	e.RawField(ErrorUnit)
+	e.Float64(s.Vertical)
+	e.RawField(ErrorUnit)
+	e.Float64(s.Spherical)
+	e.RawField(ErrorUnit)
+	return e.Encode()
+}
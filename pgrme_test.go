@@ -1,5 +1,37 @@
 package nmea_test
 
+import (
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PGRME", func() {
+	Describe("Getting data from a PGRME struct", func() {
+		var parsed PGRME
+		BeforeEach(func() {
+			sentence, err := Parse("$PGRME,3.3,M,4.9,M,6.0,M*25")
+			Expect(err).ToNot(HaveOccurred())
+			parsed = sentence.(PGRME)
+		})
+		Context("when having a complete struct", func() {
+			It("returns the estimated position errors", func() {
+				Expect(parsed.GetHorizontalAccuracy()).To(Equal(3.3))
+				Expect(parsed.GetVerticalAccuracy()).To(Equal(4.9))
+			})
+		})
+		Context("when having a struct with a missing horizontal error", func() {
+			JustBeforeEach(func() {
+				parsed.Horizontal = NewInvalidFloat64("")
+			})
+			It("returns an error", func() {
+				_, err := parsed.GetHorizontalAccuracy()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
+
 // import (
 // 	"testing"
 
@@ -0,0 +1,267 @@
+package nmea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// PipelineConfig contains the options of a Pipeline
+type PipelineConfig struct {
+	// ParseConfig is used to parse the lines, see ParseWithConfig
+	ParseConfig ParseConfig
+	// Workers is the number of goroutines that parse lines, runtime.NumCPU() is used when it is 0 or less
+	Workers int
+	// Buffer is the maximum number of lines in the pipeline, the input is not read while the pipeline
+	// is full. Twice the number of workers is used when it is less than the number of workers.
+	Buffer int
+	// Unordered emits the sentences as soon as they are parsed instead of in the order of the input
+	Unordered bool
+	// FragmentTimeout is the time to wait for the missing fragments of an AIS message, see NewAISAssembler
+	FragmentTimeout time.Duration
+}
+
+// PipelineError is sent on the ParseErrors channel when a line can not be parsed
+type PipelineError struct {
+	Index int64  // The position of the line in the input, starting at 0
+	Raw   string // The raw line
+	Err   error  // The error that occurred while parsing the line
+}
+
+// Error implements the error interface
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("nmea: line %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the parse error
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline parses lines on a pool of workers. The sentences are emitted in the order of the input,
+// unless Unordered is set. Multi-fragment AIS messages are assembled after parsing, the packet of a
+// message is set on the fragment that completes the message. The Sentences and ParseErrors channels
+// have to be drained until they are closed, a slow consumer blocks the reading of the input.
+type Pipeline struct {
+	config      PipelineConfig
+	assembler   *AISAssembler
+	inFlight    chan struct{}
+	sentences   chan Sentence
+	parseErrors chan error
+	readErrors  chan error
+}
+
+type pipelineJob struct {
+	index int64
+	raw   string
+	err   error // An error of the frame, the line is not parsed
+}
+
+type pipelineResult struct {
+	index    int64
+	raw      string
+	sentence Sentence
+	err      error
+}
+
+// NewPipeline parses the lines received on the channel until the channel is closed or the context is done
+func NewPipeline(ctx context.Context, lines <-chan string, config PipelineConfig) *Pipeline {
+	p := newPipeline(config)
+	p.start(ctx, func(jobs chan<- pipelineJob) {
+		var index int64
+		for p.acquire(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-lines:
+				if !ok {
+					return
+				}
+				jobs <- pipelineJob{index: index, raw: raw}
+				index++
+			}
+		}
+	})
+	return p
+}
+
+// NewReaderPipeline parses the frames read from the reader until the end of the input or the context
+// is done, the frames are split like the Scanner does. Errors of the reader are sent on the ReadErrors
+// channel. A read that blocks is not interrupted when the context is done.
+func NewReaderPipeline(ctx context.Context, r io.Reader, config PipelineConfig) *Pipeline {
+	p := newPipeline(config)
+	scanner := NewScanner(r)
+	p.start(ctx, func(jobs chan<- pipelineJob) {
+		var index int64
+		for p.acquire(ctx) {
+			raw, _, err := scanner.next()
+			if raw == nil {
+				if err != nil && !errors.Is(err, io.EOF) {
+					p.readErrors <- err
+				}
+				return
+			}
+			jobs <- pipelineJob{index: index, raw: string(raw), err: err}
+			index++
+		}
+	})
+	return p
+}
+
+// Sentences returns the channel of the parsed sentences, it is closed when the pipeline is done
+func (p *Pipeline) Sentences() <-chan Sentence {
+	return p.sentences
+}
+
+// ParseErrors returns the channel of the lines that could not be parsed, the errors are of type
+// *PipelineError. The channel is closed when the pipeline is done.
+func (p *Pipeline) ParseErrors() <-chan error {
+	return p.parseErrors
+}
+
+// ReadErrors returns the channel of the errors of the input, it is closed when the input is done
+func (p *Pipeline) ReadErrors() <-chan error {
+	return p.readErrors
+}
+
+func newPipeline(config PipelineConfig) *Pipeline {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.Buffer < config.Workers {
+		config.Buffer = 2 * config.Workers
+	}
+	return &Pipeline{
+		config:      config,
+		assembler:   NewAISAssembler(config.FragmentTimeout),
+		inFlight:    make(chan struct{}, config.Buffer),
+		sentences:   make(chan Sentence),
+		parseErrors: make(chan error),
+		readErrors:  make(chan error, 1),
+	}
+}
+
+// start runs the input, the workers and the output stage, read sends the jobs and returns when the input is done
+func (p *Pipeline) start(ctx context.Context, read func(jobs chan<- pipelineJob)) {
+	jobs := make(chan pipelineJob)
+	results := make(chan pipelineResult)
+
+	go func() {
+		defer close(p.readErrors)
+		defer close(jobs)
+		read(jobs)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(p.config.Workers)
+	for i := 0; i < p.config.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				select {
+				case results <- p.parse(job):
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	go func() {
+		defer close(p.parseErrors)
+		defer close(p.sentences)
+		if p.config.Unordered {
+			p.emitUnordered(ctx, results)
+		} else {
+			p.emitOrdered(ctx, results)
+		}
+	}()
+}
+
+// acquire reserves a place for a line in the pipeline, it returns false when the context is done
+func (p *Pipeline) acquire(ctx context.Context) bool {
+	select {
+	case p.inFlight <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *Pipeline) parse(job pipelineJob) pipelineResult {
+	result := pipelineResult{index: job.index, raw: job.raw, err: job.err}
+	if result.err != nil {
+		return result
+	}
+	s, err := parseSentence(job.raw, p.config.ParseConfig)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	// the fragments are assembled in the output stage, a worker only sees some of the fragments
+	s.skipFragmentAssembly = true
+	result.sentence, result.err = parseBaseSentence(s, p.config.ParseConfig)
+	return result
+}
+
+func (p *Pipeline) emitOrdered(ctx context.Context, results <-chan pipelineResult) {
+	var (
+		next    int64
+		pending = map[int64]pipelineResult{}
+	)
+	for result := range results {
+		pending[result.index] = result
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if !p.emit(ctx, r) {
+				return
+			}
+			next++
+		}
+	}
+}
+
+func (p *Pipeline) emitUnordered(ctx context.Context, results <-chan pipelineResult) {
+	for result := range results {
+		if !p.emit(ctx, result) {
+			return
+		}
+	}
+}
+
+// emit sends the result to the consumer, it returns false when the context is done
+func (p *Pipeline) emit(ctx context.Context, r pipelineResult) bool {
+	defer func() { <-p.inFlight }()
+	if r.err != nil {
+		select {
+		case p.parseErrors <- &PipelineError{Index: r.index, Raw: r.raw, Err: r.err}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	if m, ok := r.sentence.(VDMVDO); ok && m.NumFragments.Value > 1 {
+		// a first fragment only restarts a message when the fragments are emitted in order
+		if assembled, ok, _ := p.assembler.addAt(m, time.Now(), !p.config.Unordered); ok {
+			m.Packet = assembled.Packet
+			r.sentence = m
+		}
+	}
+	select {
+	case p.sentences <- r.sentence:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,187 @@
+package nmea_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing/iotest"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline", func() {
+	const (
+		fragment1 = "!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11"
+		fragment2 = "!AIVDM,2,2,1,B,Q0C@00000000000,2*44"
+	)
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		config PipelineConfig
+	)
+	headings := func(n int) []string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = withChecksum(fmt.Sprintf("GPHDT,%d.0,T", i))
+		}
+		return lines
+	}
+	send := func(lines []string) <-chan string {
+		c := make(chan string)
+		go func() {
+			defer close(c)
+			for _, line := range lines {
+				c <- line
+			}
+		}()
+		return c
+	}
+	collect := func(p *Pipeline) ([]Sentence, []error) {
+		var (
+			sentences []Sentence
+			errs      []error
+		)
+		sentenceChan, errChan := p.Sentences(), p.ParseErrors()
+		for sentenceChan != nil || errChan != nil {
+			select {
+			case s, ok := <-sentenceChan:
+				if !ok {
+					sentenceChan = nil
+					continue
+				}
+				sentences = append(sentences, s)
+			case err, ok := <-errChan:
+				if !ok {
+					errChan = nil
+					continue
+				}
+				errs = append(errs, err)
+			}
+		}
+		return sentences, errs
+	}
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		config = PipelineConfig{Workers: 4}
+	})
+	AfterEach(func() {
+		cancel()
+	})
+	Context("when the lines are parsed in order", func() {
+		It("emits the sentences in the order of the input", func() {
+			lines := headings(500)
+			sentences, errs := collect(NewPipeline(ctx, send(lines), config))
+			Expect(errs).To(BeEmpty())
+			Expect(sentences).To(HaveLen(len(lines)))
+			for i, s := range sentences {
+				Expect(s.String()).To(Equal(lines[i]))
+			}
+		})
+	})
+	Context("when the lines are parsed unordered", func() {
+		BeforeEach(func() {
+			config.Unordered = true
+		})
+		It("emits all sentences", func() {
+			lines := headings(500)
+			sentences, errs := collect(NewPipeline(ctx, send(lines), config))
+			Expect(errs).To(BeEmpty())
+			raws := make([]string, len(sentences))
+			for i, s := range sentences {
+				raws[i] = s.String()
+			}
+			Expect(raws).To(ConsistOf(lines))
+		})
+	})
+	Context("when a line can not be parsed", func() {
+		It("sends the error with the position of the line", func() {
+			lines := []string{"$GPHDT,123.456,T*32", "$GPHDT,123.456,T*FF", "$GPHDT,123.456,T*32"}
+			sentences, errs := collect(NewPipeline(ctx, send(lines), config))
+			Expect(sentences).To(HaveLen(2))
+			Expect(errs).To(HaveLen(1))
+			var pipelineErr *PipelineError
+			Expect(errors.As(errs[0], &pipelineErr)).To(BeTrue())
+			Expect(pipelineErr.Index).To(Equal(int64(1)))
+			Expect(pipelineErr.Raw).To(Equal(lines[1]))
+			Expect(errs[0]).To(MatchError(ErrChecksumMismatch))
+			Expect(errs[0]).To(MatchError("nmea: line 1: nmea: sentence checksum mismatch [32 != FF]"))
+		})
+	})
+	Context("when the fragments of AIS messages are parsed by different workers", func() {
+		It("assembles the messages", func() {
+			var lines []string
+			for i := 0; i < 100; i++ {
+				lines = append(lines, fragment1, fragment2)
+			}
+			sentences, errs := collect(NewPipeline(ctx, send(lines), config))
+			Expect(errs).To(BeEmpty())
+			Expect(sentences).To(HaveLen(len(lines)))
+			for i, s := range sentences {
+				if i%2 == 0 {
+					Expect(s.(VDMVDO).Packet).To(BeNil())
+				} else {
+					Expect(s.(VDMVDO).GetMMSI()).To(Equal("244660797"))
+				}
+			}
+		})
+	})
+	Context("when the consumer does not read the sentences", func() {
+		BeforeEach(func() {
+			config = PipelineConfig{Workers: 1, Buffer: 2}
+		})
+		It("does not read more lines than the buffer", func() {
+			lines := make(chan string)
+			p := NewPipeline(ctx, lines, config)
+			lines <- withChecksum("GPHDT,1.0,T")
+			lines <- withChecksum("GPHDT,2.0,T")
+			sent := make(chan struct{})
+			go func() {
+				defer close(sent)
+				lines <- withChecksum("GPHDT,3.0,T")
+			}()
+			Consistently(sent, 100*time.Millisecond).ShouldNot(BeClosed())
+			Expect((<-p.Sentences()).String()).To(Equal(withChecksum("GPHDT,1.0,T")))
+			Eventually(sent).Should(BeClosed())
+		})
+	})
+	Context("when the context is canceled", func() {
+		It("closes the channels", func() {
+			lines := make(chan string)
+			p := NewPipeline(ctx, lines, config)
+			lines <- withChecksum("GPHDT,1.0,T")
+			cancel()
+			Eventually(p.Sentences()).Should(BeClosed())
+			Eventually(p.ParseErrors()).Should(BeClosed())
+			Eventually(p.ReadErrors()).Should(BeClosed())
+		})
+	})
+	Context("when reading from a reader", func() {
+		It("parses the frames in order", func() {
+			lines := headings(100)
+			p := NewReaderPipeline(ctx, strings.NewReader(strings.Join(lines, "\r\n")), config)
+			sentences, errs := collect(p)
+			Expect(errs).To(BeEmpty())
+			Expect(sentences).To(HaveLen(len(lines)))
+			for i, s := range sentences {
+				Expect(s.String()).To(Equal(lines[i]))
+			}
+			Eventually(p.ReadErrors()).Should(BeClosed())
+		})
+		It("sends the errors of the reader", func() {
+			readErr := errors.New("read error")
+			lines := headings(2)
+			r := io.MultiReader(strings.NewReader(strings.Join(lines, "\r\n")+"\r\n"), iotest.ErrReader(readErr))
+			p := NewReaderPipeline(ctx, r, config)
+			sentences, errs := collect(p)
+			Expect(errs).To(BeEmpty())
+			Expect(sentences).To(HaveLen(len(lines)))
+			Expect(p.ReadErrors()).To(Receive(Equal(readErr)))
+			Expect(p.ReadErrors()).To(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package nmea
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProprietaryStart is the first character of the address field of a proprietary sentence
+	ProprietaryStart = "P"
+)
+
+// Manufacturer is a manufacturer with a three letter mnemonic for proprietary sentences
+type Manufacturer struct {
+	Code string // The three letter mnemonic, e.g. GRM
+	Name string // The name of the manufacturer, e.g. Garmin
+}
+
+// knownManufacturers are the manufacturers of the default registry
+var knownManufacturers = map[string]Manufacturer{
+	"ASH": {Code: "ASH", Name: "Ashtech"},
+	"FEC": {Code: "FEC", Name: "Furuno"},
+	"GRM": {Code: "GRM", Name: "Garmin"},
+	"KWD": {Code: "KWD", Name: "Kenwood"},
+	"MTK": {Code: "MTK", Name: "MediaTek"},
+	"SRF": {Code: "SRF", Name: "SiRF"},
+	"STI": {Code: "STI", Name: "SkyTraq"},
+	"TNL": {Code: "TNL", Name: "Trimble"},
+	"UBX": {Code: "UBX", Name: "u-blox"},
+}
+
+// RegisterManufacturer adds a manufacturer to the known manufacturers of the default registry,
+// see Registry.RegisterManufacturer
+func RegisterManufacturer(code string, name string) error {
+	return defaultRegistry.RegisterManufacturer(code, name)
+}
+
+// LookupManufacturer returns the known manufacturer of the code of the default registry
+func LookupManufacturer(code string) (Manufacturer, bool) {
+	return defaultRegistry.LookupManufacturer(code)
+}
+
+// Proprietary is a proprietary sentence, the address field starts with a P followed by the three
+// letter manufacturer mnemonic. The sub message id follows the mnemonic in the address field,
+// e.g. $PGRME or $PSRF103, or is the first field when the address field only contains the
+// mnemonic, e.g. $PUBX,00.
+type Proprietary struct {
+	BaseSentence
+	Manufacturer string   // The three letter manufacturer mnemonic, e.g. GRM
+	SubID        string   // The sub message id, e.g. E for $PGRME and 00 for $PUBX,00
+	Payload      []string // The fields after the sub message id
+}
+
+// ProprietaryParserFunc callback used to parse proprietary sentences
+type ProprietaryParserFunc func(Proprietary) (Sentence, error)
+
+// NewProprietary splits the base sentence into the parts of a proprietary sentence
+func NewProprietary(s BaseSentence) (Proprietary, error) {
+	manufacturer, subID := s.proprietaryAddress()
+	if manufacturer == "" {
+		return Proprietary{}, fmt.Errorf("nmea: %s is not a proprietary sentence", s.Prefix())
+	}
+	p := Proprietary{
+		BaseSentence: s,
+		Manufacturer: manufacturer,
+		SubID:        subID,
+		Payload:      s.Fields,
+	}
+	if subID == "" && len(s.Fields) > 0 {
+		p.SubID = s.Fields[0]
+		p.Payload = s.Fields[1:]
+	}
+	return p, nil
+}
+
+// Encode serializes the proprietary sentence into NMEA 0183 text
+func (s Proprietary) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	if _, subID := s.proprietaryAddress(); subID == "" {
+		e.RawField(s.SubID)
+	}
+	e.RawField(s.Payload...)
+	return e.Encode()
+}
+
+// proprietaryAddress returns the manufacturer mnemonic and the sub message id of the address field,
+// an empty manufacturer is returned when the sentence is not a proprietary sentence
+func (s BaseSentence) proprietaryAddress() (manufacturer string, subID string) {
+	if !strings.HasPrefix(s.Talker, ProprietaryStart) {
+		return "", ""
+	}
+	address := s.Prefix()
+	if len(address) < 4 {
+		return "", ""
+	}
+	return address[1:4], address[4:]
+}
+
+// proprietary wraps a ProprietaryParserFunc into a ParserFunc
+func proprietary(parser ProprietaryParserFunc) ParserFunc {
+	return func(s BaseSentence) (Sentence, error) {
+		p, err := NewProprietary(s)
+		if err != nil {
+			return nil, err
+		}
+		return parser(p)
+	}
+}
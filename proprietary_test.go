@@ -0,0 +1,109 @@
+package nmea_test
+
+import (
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proprietary", func() {
+	proprietaryOf := func(raw string) (Proprietary, error) {
+		s, err := ParseWithConfig(raw, ParseConfig{UnknownPassthrough: true})
+		Expect(err).ToNot(HaveOccurred())
+		return NewProprietary(s.(Unknown).BaseSentence)
+	}
+	DescribeTable("Splitting the address field",
+		func(raw string, manufacturer string, subID string, payload []string) {
+			p, err := proprietaryOf(raw)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.Manufacturer).To(Equal(manufacturer))
+			Expect(p.SubID).To(Equal(subID))
+			Expect(p.Payload).To(Equal(payload))
+		},
+		Entry("sub id in the address field", withChecksum("PSRF103,00,01,00,01"), "SRF", "103", []string{"00", "01", "00", "01"}),
+		Entry("single letter sub id", withChecksum("PASHR,085335.000,224.19,T,-01.26,+00.83,,0.101,0.113,0.267,1,0"), "ASH", "R", []string{"085335.000", "224.19", "T", "-01.26", "+00.83", "", "0.101", "0.113", "0.267", "1", "0"}),
+		Entry("sub id in the first field", withChecksum("PUBX,00,081350.00,4717.113210,N"), "UBX", "00", []string{"081350.00", "4717.113210", "N"}),
+	)
+	Context("when the sentence is not proprietary", func() {
+		It("returns an error", func() {
+			s, _ := Parse("$GPHDT,123.456,T*32")
+			_, err := NewProprietary(s.(HDT).BaseSentence)
+			Expect(err).To(MatchError("nmea: GPHDT is not a proprietary sentence"))
+		})
+	})
+	Context("when encoding", func() {
+		It("returns the original sentence", func() {
+			for _, raw := range []string{withChecksum("PUBX,00,081350.00,4717.113210,N"), withChecksum("PSRF103,00,01,00,01")} {
+				p, _ := proprietaryOf(raw)
+				Expect(p.Encode()).To(Equal(raw))
+			}
+		})
+	})
+	Context("when parsing the built-in proprietary sentences", func() {
+		It("returns the manufacturer and sub id", func() {
+			s, err := Parse("$PGRME,3.3,M,4.9,M,6.0,M*25")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(PGRME).Manufacturer).To(Equal(ManufacturerGarmin))
+			Expect(s.(PGRME).SubID).To(Equal("E"))
+			s, err = Parse("$PMTK001,604,3*32")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(MTK).Manufacturer).To(Equal(ManufacturerMTK))
+			Expect(s.(MTK).SubID).To(Equal("001"))
+		})
+	})
+	Context("when registering a proprietary parser", func() {
+		var registry *Registry
+		BeforeEach(func() {
+			registry = DefaultRegistry().Clone()
+			Expect(registry.RegisterProprietary("UBX", "", func(p Proprietary) (Sentence, error) {
+				return p, nil
+			})).To(Succeed())
+			Expect(registry.RegisterProprietary("UBX", "00", func(p Proprietary) (Sentence, error) {
+				p.Payload = nil
+				return p, nil
+			})).To(Succeed())
+		})
+		It("uses the parser of the sub id", func() {
+			s, err := ParseWithConfig(withChecksum("PUBX,00,081350.00,4717.113210,N"), ParseConfig{Registry: registry})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(Proprietary).Payload).To(BeNil())
+		})
+		It("uses the parser of the manufacturer for other sub ids", func() {
+			s, err := ParseWithConfig(withChecksum("PUBX,03,11"), ParseConfig{Registry: registry})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(Proprietary).SubID).To(Equal("03"))
+			Expect(s.(Proprietary).Payload).To(Equal([]string{"11"}))
+		})
+		It("requires a manufacturer for a sub id", func() {
+			Expect(registry.Register(ParserKey{SubID: "00"}, nil)).To(MatchError("nmea: parser key requires a manufacturer for sub id '00'"))
+		})
+	})
+	Describe("Manufacturers", func() {
+		var registry *Registry
+		BeforeEach(func() {
+			registry = DefaultRegistry().Clone()
+		})
+		It("contains the known manufacturers", func() {
+			m, ok := LookupManufacturer("GRM")
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(Manufacturer{Code: "GRM", Name: "Garmin"}))
+			m, ok = registry.LookupManufacturer("MTK")
+			Expect(ok).To(BeTrue())
+			Expect(m.Code).To(Equal("MTK"))
+		})
+		It("registers a manufacturer", func() {
+			Expect(registry.RegisterManufacturer("QQQ", "Test")).To(Succeed())
+			m, ok := registry.LookupManufacturer("QQQ")
+			Expect(ok).To(BeTrue())
+			Expect(m).To(Equal(Manufacturer{Code: "QQQ", Name: "Test"}))
+			Expect(registry.RegisterManufacturer("QQQ", "Test")).To(MatchError("nmea: manufacturer 'QQQ' already exists"))
+			_, ok = LookupManufacturer("QQQ")
+			Expect(ok).To(BeFalse())
+		})
+		It("rejects invalid codes", func() {
+			Expect(registry.RegisterManufacturer("qq", "Test")).To(HaveOccurred())
+			_, ok := registry.LookupManufacturer("ZZZ")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,312 @@
+package nmea
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ParserKey identifies the sentences a parser is registered for. Exactly one of Prefix, Type
+// and Manufacturer has to be set. When a sentence matches multiple keys the parser of the Prefix
+// key is used before the Type key and the Manufacturer key, a Manufacturer key with a SubID is used
+// before the same key without a SubID and a key with a Start is used before the same key without a Start.
+type ParserKey struct {
+	Start        string // SentenceStart or SentenceStartEncapsulated, empty for both
+	Prefix       string // The talker and data type, e.g. GPRMC
+	Type         string // The data type for all talkers, e.g. RMC
+	Manufacturer string // The manufacturer mnemonic of proprietary sentences, e.g. GRM for $PGRME
+	SubID        string // The sub message id of proprietary sentences, e.g. E for $PGRME, requires Manufacturer
+}
+
+// String formats the key for error messages
+func (k ParserKey) String() string {
+	var s string
+	switch {
+	case k.Prefix != "":
+		s = "prefix '" + k.Prefix + "'"
+	case k.Type != "":
+		s = "sentence type '" + k.Type + "'"
+	default:
+		s = "manufacturer '" + k.Manufacturer + "'"
+		if k.SubID != "" {
+			s += " sub id '" + k.SubID + "'"
+		}
+	}
+	if k.Start != "" {
+		s += " starting with '" + k.Start + "'"
+	}
+	return s
+}
+
+func (k ParserKey) validate() error {
+	if k.SubID != "" && k.Manufacturer == "" {
+		return fmt.Errorf("nmea: parser key requires a manufacturer for sub id '%s'", k.SubID)
+	}
+	n := 0
+	for _, v := range []string{k.Prefix, k.Type, k.Manufacturer} {
+		if v != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("nmea: parser key requires exactly one of prefix, type and manufacturer")
+	}
+	if k.Start != "" && k.Start != SentenceStart && k.Start != SentenceStartEncapsulated {
+		return fmt.Errorf("nmea: parser key has an invalid start '%s'", k.Start)
+	}
+	return nil
+}
+
+type registryEntry struct {
+	parser  ParserFunc
+	builtin bool
+}
+
+// Registry contains the parsers for the sentence types. A Registry is safe for concurrent use
+// by multiple goroutines. The zero value is an empty registry.
+type Registry struct {
+	mu            sync.RWMutex
+	parsers       map[ParserKey]registryEntry
+	prefixes      int // The number of Prefix keys, the prefix is only joined when there are Prefix keys
+	manufacturers map[string]Manufacturer
+	schemas       map[string]schemaEntry
+}
+
+// defaultRegistry is used by Parse and RegisterParser
+var defaultRegistry = newDefaultRegistry()
+
+// NewRegistry creates an empty registry, use DefaultRegistry().Clone() to start from the built-in types
+func NewRegistry() *Registry {
+	return &Registry{
+		parsers:       map[ParserKey]registryEntry{},
+		manufacturers: map[string]Manufacturer{},
+		schemas:       map[string]schemaEntry{},
+	}
+}
+
+// DefaultRegistry returns the package registry that is used when no other registry is configured,
+// it contains the built-in types and the parsers registered with RegisterParser
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Clone returns a copy of the registry, changes to the copy do not affect the original registry
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := NewRegistry()
+	for key, entry := range r.parsers {
+		c.parsers[key] = entry
+	}
+	c.prefixes = r.prefixes
+	for code, m := range r.manufacturers {
+		c.manufacturers[code] = m
+	}
+	for typ, entry := range r.schemas {
+		c.schemas[typ] = entry
+	}
+	return c
+}
+
+// RegisterManufacturer adds a manufacturer to the known manufacturers of the registry, the code
+// has to be a three letter upper case mnemonic
+func (r *Registry) RegisterManufacturer(code string, name string) error {
+	if len(code) != 3 || strings.ToUpper(code) != code {
+		return fmt.Errorf("nmea: manufacturer code '%s' is not a three letter upper case mnemonic", code)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.manufacturers[code]; ok {
+		return fmt.Errorf("nmea: manufacturer '%s' already exists", code)
+	}
+	if r.manufacturers == nil {
+		r.manufacturers = map[string]Manufacturer{}
+	}
+	r.manufacturers[code] = Manufacturer{Code: code, Name: name}
+	return nil
+}
+
+// LookupManufacturer returns the known manufacturer of the code
+func (r *Registry) LookupManufacturer(code string) (Manufacturer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.manufacturers[code]
+	return m, ok
+}
+
+// Register adds a parser for the key. A parser of a built-in type is replaced, a key without a
+// Start replaces the built-in parsers of both start delimiters. An error is returned when another
+// parser is already registered for the key.
+func (r *Registry) Register(key ParserKey, parser ParserFunc) error {
+	if err := key.validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry, ok := r.parsers[key]; ok && !entry.builtin {
+		return fmt.Errorf("nmea: parser for %s already exists", key)
+	}
+	r.removeBuiltins(key)
+	r.set(key, registryEntry{parser: parser})
+	return nil
+}
+
+// RegisterProprietary adds a parser for the proprietary sentences of the manufacturer, the parser
+// is used for all sentences of the manufacturer when the subID is empty, see Register
+func (r *Registry) RegisterProprietary(manufacturer string, subID string, parser ProprietaryParserFunc) error {
+	return r.Register(ParserKey{Manufacturer: manufacturer, SubID: subID}, proprietary(parser))
+}
+
+// Override adds a parser for the key, a parser that is already registered for the key is replaced,
+// a key without a Start replaces the built-in parsers of both start delimiters
+func (r *Registry) Override(key ParserKey, parser ParserFunc) error {
+	if err := key.validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removeBuiltins(key)
+	r.set(key, registryEntry{parser: parser})
+	return nil
+}
+
+// Remove removes the parser of the key, it returns false when there was no parser for the key
+func (r *Registry) Remove(key ParserKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.parsers[key]; !ok {
+		return false
+	}
+	delete(r.parsers, key)
+	if key.Prefix != "" {
+		r.prefixes--
+	}
+	return true
+}
+
+// Lookup returns the parser for the sentence
+func (r *Registry) Lookup(s BaseSentence) (ParserFunc, bool) {
+	start := ""
+	if len(s.Raw) > 0 {
+		start = s.Raw[:1]
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]ParserKey, 0, 8)
+	if r.prefixes > 0 {
+		prefix := s.Prefix()
+		keys = append(keys, ParserKey{Start: start, Prefix: prefix}, ParserKey{Prefix: prefix})
+	}
+	keys = append(keys, ParserKey{Start: start, Type: s.Type}, ParserKey{Type: s.Type})
+	if manufacturer, subID := s.proprietaryAddress(); manufacturer != "" {
+		if subID == "" && len(s.Fields) > 0 {
+			subID = s.Fields[0]
+		}
+		keys = append(keys,
+			ParserKey{Start: start, Manufacturer: manufacturer, SubID: subID},
+			ParserKey{Manufacturer: manufacturer, SubID: subID},
+			ParserKey{Start: start, Manufacturer: manufacturer},
+			ParserKey{Manufacturer: manufacturer},
+		)
+	}
+	for _, key := range keys {
+		if entry, ok := r.parsers[key]; ok {
+			return entry.parser, true
+		}
+	}
+	return nil, false
+}
+
+// registerType adds a parser for the sentence type for both start delimiters, see RegisterParser
+func (r *Registry) registerType(sentenceType string, parser ParserFunc) error {
+	keys := []ParserKey{
+		{Start: SentenceStart, Type: sentenceType},
+		{Start: SentenceStartEncapsulated, Type: sentenceType},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		if entry, ok := r.parsers[key]; ok && !entry.builtin {
+			return fmt.Errorf("nmea: parser for sentence type '%q' already exists", sentenceType)
+		}
+	}
+	for _, key := range keys {
+		r.set(key, registryEntry{parser: parser})
+	}
+	return nil
+}
+
+// removeBuiltins removes the built-in parsers of the key with a Start when the key has no Start,
+// otherwise they would be used before the parser of the key
+func (r *Registry) removeBuiltins(key ParserKey) {
+	if key.Start != "" {
+		return
+	}
+	for _, start := range []string{SentenceStart, SentenceStartEncapsulated} {
+		k := key
+		k.Start = start
+		if entry, ok := r.parsers[k]; ok && entry.builtin {
+			delete(r.parsers, k)
+		}
+	}
+}
+
+func (r *Registry) set(key ParserKey, entry registryEntry) {
+	if r.parsers == nil {
+		r.parsers = map[ParserKey]registryEntry{}
+	}
+	if _, ok := r.parsers[key]; !ok && key.Prefix != "" {
+		r.prefixes++
+	}
+	r.parsers[key] = entry
+}
+
+// builtin wraps the constructor of a built-in type into a ParserFunc
+func builtin[T Sentence](constructor func(BaseSentence) (T, error)) ParserFunc {
+	return func(s BaseSentence) (Sentence, error) {
+		return constructor(s)
+	}
+}
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for code, m := range knownManufacturers {
+		r.manufacturers[code] = m
+	}
+	registerSchemas(r)
+	for typ, parser := range map[string]ParserFunc{
+		TypeRMC: builtin(newRMC),
+		TypeGGA: builtin(newGGA),
+		TypeGSA: builtin(newGSA),
+		TypeGLL: builtin(newGLL),
+		TypeVTG: builtin(newVTG),
+		TypeZDA: builtin(newZDA),
+		TypeGSV: builtin(newGSV),
+		TypeHDT: builtin(newHDT),
+		TypeGNS: builtin(newGNS),
+		TypeTHS: builtin(newTHS),
+		TypeWPL: builtin(newWPL),
+		TypeRTE: builtin(newRTE),
+		TypeVHW: builtin(newVHW),
+		TypeDPT: builtin(newDPT),
+		TypeDBT: builtin(newDBT),
+		TypeDBS: builtin(newDBS),
+		TypeHEV: builtin(newHEV),
+		TypeMDA: builtin(newMDA),
+		TypeMWD: builtin(newMWD),
+		TypeMWV: builtin(newMWV),
+		TypeROT: builtin(newROT),
+		TypeRSA: builtin(newRSA),
+		TypeVWR: builtin(newVWR),
+		TypeGST: builtin(newGST),
+		TypeALR: builtin(newALR),
+	} {
+		r.set(ParserKey{Start: SentenceStart, Type: typ}, registryEntry{parser: parser, builtin: true})
+	}
+	r.set(ParserKey{Start: SentenceStart, Manufacturer: ManufacturerGarmin, SubID: "E"}, registryEntry{parser: builtin(newPGRME), builtin: true})
+	// MTK message types share the same format so we return the same struct for all types.
+	r.set(ParserKey{Start: SentenceStart, Manufacturer: ManufacturerMTK}, registryEntry{parser: builtin(newMTK), builtin: true})
+	r.set(ParserKey{Start: SentenceStartEncapsulated, Type: TypeVDM}, registryEntry{parser: builtin(newVDMVDO), builtin: true})
+	r.set(ParserKey{Start: SentenceStartEncapsulated, Type: TypeVDO}, registryEntry{parser: builtin(newVDMVDO), builtin: true})
+	return r
+}
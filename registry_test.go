@@ -0,0 +1,151 @@
+package nmea_test
+
+import (
+	"sync"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type registryTestSentence struct {
+	BaseSentence
+	Parser string
+}
+
+func registryTestParser(name string) ParserFunc {
+	return func(s BaseSentence) (Sentence, error) {
+		return registryTestSentence{BaseSentence: s, Parser: name}, nil
+	}
+}
+
+var _ = Describe("Registry", func() {
+	var registry *Registry
+	parse := func(raw string) (Sentence, error) {
+		return ParseWithConfig(raw, ParseConfig{Registry: registry})
+	}
+	parserOf := func(raw string) string {
+		s, err := parse(raw)
+		Expect(err).ToNot(HaveOccurred())
+		return s.(registryTestSentence).Parser
+	}
+	BeforeEach(func() {
+		registry = DefaultRegistry().Clone()
+	})
+	Context("when cloning the default registry", func() {
+		It("contains the built-in types", func() {
+			s, err := parse("$GPHDT,123.456,T*32")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(BeAssignableToTypeOf(HDT{}))
+			s, err = parse("!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(BeAssignableToTypeOf(VDMVDO{}))
+		})
+		It("does not change the default registry", func() {
+			Expect(registry.Remove(ParserKey{Start: SentenceStart, Type: TypeHDT})).To(BeTrue())
+			_, err := parse("$GPHDT,123.456,T*32")
+			Expect(err).To(MatchError(ErrUnsupportedPrefix))
+			_, err = Parse("$GPHDT,123.456,T*32")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	Context("when creating an empty registry", func() {
+		It("does not contain any types", func() {
+			registry = NewRegistry()
+			_, err := parse("$GPHDT,123.456,T*32")
+			Expect(err).To(MatchError(ErrUnsupportedPrefix))
+		})
+	})
+	Context("when registering a parser for a built-in type", func() {
+		It("replaces the built-in parser", func() {
+			Expect(registry.Register(ParserKey{Start: SentenceStart, Type: TypeHDT}, registryTestParser("custom"))).To(Succeed())
+			Expect(parserOf("$GPHDT,123.456,T*32")).To(Equal("custom"))
+		})
+		It("replaces the built-in parser with a parser for the type", func() {
+			Expect(registry.Register(ParserKey{Type: TypeHDT}, registryTestParser("custom"))).To(Succeed())
+			Expect(parserOf("$GPHDT,123.456,T*32")).To(Equal("custom"))
+			Expect(registry.Register(ParserKey{Type: TypeVDM}, registryTestParser("custom"))).To(Succeed())
+			Expect(parserOf("!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")).To(Equal("custom"))
+		})
+		It("replaces the built-in parser when the type is overridden", func() {
+			Expect(registry.Override(ParserKey{Type: TypeHDT}, registryTestParser("custom"))).To(Succeed())
+			Expect(parserOf("$GPHDT,123.456,T*32")).To(Equal("custom"))
+		})
+	})
+	Context("when registering a parser twice", func() {
+		It("returns an error", func() {
+			Expect(registry.Register(ParserKey{Type: "RGT"}, registryTestParser("first"))).To(Succeed())
+			Expect(registry.Register(ParserKey{Type: "RGT"}, registryTestParser("second"))).To(MatchError("nmea: parser for sentence type 'RGT' already exists"))
+		})
+		It("can be overridden", func() {
+			Expect(registry.Register(ParserKey{Type: "RGT"}, registryTestParser("first"))).To(Succeed())
+			Expect(registry.Override(ParserKey{Type: "RGT"}, registryTestParser("second"))).To(Succeed())
+			Expect(parserOf(withChecksum("GPRGT,1"))).To(Equal("second"))
+		})
+	})
+	Context("when registering an invalid key", func() {
+		It("returns an error", func() {
+			Expect(registry.Register(ParserKey{}, registryTestParser("none"))).To(MatchError("nmea: parser key requires exactly one of prefix, type and manufacturer"))
+			Expect(registry.Register(ParserKey{Type: "RGT", Prefix: "GPRGT"}, registryTestParser("both"))).To(HaveOccurred())
+			Expect(registry.Register(ParserKey{Start: "#", Type: "RGT"}, registryTestParser("start"))).To(MatchError("nmea: parser key has an invalid start '#'"))
+		})
+	})
+	Context("when removing a parser that is not registered", func() {
+		It("returns false", func() {
+			Expect(registry.Remove(ParserKey{Type: "RGT"})).To(BeFalse())
+		})
+	})
+	Context("when parsers are registered for a prefix and a type", func() {
+		BeforeEach(func() {
+			Expect(registry.Register(ParserKey{Type: "RGT"}, registryTestParser("type"))).To(Succeed())
+			Expect(registry.Register(ParserKey{Prefix: "IIRGT"}, registryTestParser("prefix"))).To(Succeed())
+		})
+		It("uses the parser of the prefix for that talker", func() {
+			Expect(parserOf(withChecksum("IIRGT,1"))).To(Equal("prefix"))
+			Expect(parserOf(withChecksum("GPRGT,1"))).To(Equal("type"))
+		})
+	})
+	Context("when parsers are registered for a start delimiter", func() {
+		BeforeEach(func() {
+			Expect(registry.Register(ParserKey{Type: "RGT"}, registryTestParser("both"))).To(Succeed())
+			Expect(registry.Register(ParserKey{Start: SentenceStartEncapsulated, Type: "RGT"}, registryTestParser("encapsulated"))).To(Succeed())
+		})
+		It("uses the parser of the start delimiter", func() {
+			Expect(parserOf(withChecksum("GPRGT,1"))).To(Equal("both"))
+			Expect(parserOf("!GPRGT,1*" + Checksum("GPRGT,1"))).To(Equal("encapsulated"))
+		})
+	})
+	Context("when a parser is registered for a manufacturer", func() {
+		BeforeEach(func() {
+			Expect(registry.Register(ParserKey{Manufacturer: "ACM"}, registryTestParser("manufacturer"))).To(Succeed())
+		})
+		It("uses the parser for all sentences of the manufacturer", func() {
+			Expect(parserOf(withChecksum("PACMA,1"))).To(Equal("manufacturer"))
+			Expect(parserOf(withChecksum("PACMB,1"))).To(Equal("manufacturer"))
+		})
+		It("uses the parser of the type before the parser of the manufacturer", func() {
+			Expect(registry.Register(ParserKey{Type: "ACMB"}, registryTestParser("type"))).To(Succeed())
+			Expect(parserOf(withChecksum("PACMB,1"))).To(Equal("type"))
+		})
+	})
+	Context("when used from multiple goroutines", func() {
+		It("does not race", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					_, err := parse("$GPHDT,123.456,T*32")
+					Expect(err).ToNot(HaveOccurred())
+				}()
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					Expect(registry.Override(ParserKey{Prefix: "GPRGT"}, registryTestParser("concurrent"))).To(Succeed())
+				}()
+			}
+			wg.Wait()
+		})
+	})
+})
@@ -28,6 +28,8 @@ type RMC struct {
 	Course    Float64 // True course
 	Date      Date    // Date
 	Variation Float64 // Magnetic variation
+	FAAMode   String  // FAA mode indicator, NMEA 2.3 and later
+	NavStatus String  // Navigational status, NMEA 4.1 and later
 }
 
 // newRMC constructor
@@ -43,6 +45,8 @@ func newRMC(s BaseSentence) (RMC, error) {
 		Speed:        p.Float64(6, "speed"),
 		Course:       p.Float64(7, "course"),
 		Date:         p.Date(8, "date"),
+		FAAMode:      p.OptionalString(11, "FAA mode"),
+		NavStatus:    p.OptionalString(12, "navigational status"),
 		Variation:    p.Float64(9, "variation"),
 	}
 	if m.Variation.Valid && p.EnumString(10, "direction", West, East).Value == West {
@@ -95,19 +99,55 @@ func (s RMC) GetSpeedOverGround() (float64, error) {
 
 // GetDateTime retrieves the date and time in RFC3339Nano format
 func (s RMC) GetDateTime() (string, error) {
+	t, err := s.GetTimestamp()
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// GetTimestamp retrieves the date and time in UTC from the sentence
+func (s RMC) GetTimestamp() (time.Time, error) {
 	if s.Validity.Value == ValidRMC {
-		if s.Date.Valid && s.Time.Valid {
-			return time.Date(
-				s.Date.YY,
-				time.Month(s.Date.MM),
-				s.Date.DD,
-				s.Time.Hour,
-				s.Time.Minute,
-				s.Time.Second,
-				s.Time.Millisecond*1000000,
-				time.UTC,
-			).UTC().Format(time.RFC3339Nano), nil
+		if t, err := s.dateConfig.Time(s.Date, s.Time); err == nil {
+			return t, nil
 		}
 	}
-	return "", fmt.Errorf("value is unavailable")
+	return time.Time{}, fmt.Errorf("value is unavailable")
+}
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s RMC) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the RMC sentence into NMEA 0183 text
+func (s RMC) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.StringField(s.Validity)
+	e.Latitude(s.Latitude)
+	e.Longitude(s.Longitude)
+	e.Float64(s.Speed)
+	e.Float64(s.Course)
+	e.Date(s.Date)
+	if s.Variation.Valid && s.Variation.Value < 0 {
+		e.Float64(NewFloat64(0 - s.Variation.Value))
+		e.RawField(West)
+	} else if s.Variation.Valid {
+		e.Float64(s.Variation)
+		e.RawField(East)
+	} else {
+		e.RawField("", "")
+	}
+	if s.FAAMode.Valid || s.NavStatus.Valid {
+		e.StringField(s.FAAMode)
+	}
+	if s.NavStatus.Valid {
+		e.StringField(s.NavStatus)
+	}
+	return e.Encode()
 }
@@ -1,6 +1,8 @@
 package nmea_test
 
 import (
+	"time"
+
 	. "github.com/munnik/go-nmea"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -40,6 +42,8 @@ var _ = Describe("RMC", func() {
 					"Course":    Equal(NewFloat64(345.6)),
 					"Date":      Equal(NewDate(21, 4, 23)),
 					"Variation": Equal(NewFloat64(0.3)),
+					"FAAMode":   Equal(NewString("A")),
+					"NavStatus": Equal(NewString("C")),
 				}))
 			})
 		})
@@ -60,6 +64,8 @@ var _ = Describe("RMC", func() {
 					"Course":    Equal(NewFloat64(231.8)),
 					"Date":      Equal(NewDate(94, 6, 13)),
 					"Variation": Equal(NewFloat64(-4.2)),
+					"FAAMode":   Equal(NewInvalidString("index out of range")),
+					"NavStatus": Equal(NewInvalidString("index out of range")),
 				}))
 			})
 		})
@@ -116,6 +122,14 @@ var _ = Describe("RMC", func() {
 			})
 			It("returns a valid date and time", func() {
 				Expect(parsed.GetDateTime()).To(Equal("2021-04-16T20:05:45.315Z"))
+				Expect(parsed.GetTimestamp()).To(Equal(time.Date(2021, 4, 16, 20, 5, 45, 315000000, time.UTC)))
+			})
+			It("returns a valid time of day", func() {
+				Expect(parsed.GetTimeOfDay()).To(Equal(20*time.Hour + 5*time.Minute + 45*time.Second + 315*time.Millisecond))
+			})
+			It("returns a date and time in the 21st century for a two digit year", func() {
+				parsed.Date = NewDate(21, 4, 16)
+				Expect(parsed.GetDateTime()).To(Equal("2021-04-16T20:05:45.315Z"))
 			})
 		})
 		Context("when having a struct with the validity flag set to invalid", func() {
@@ -45,3 +45,11 @@ func (s ROT) GetRateOfTurn() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the ROT sentence into NMEA 0183 text
+func (s ROT) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.RateOfTurn)
+	e.StringField(s.Status)
+	return e.Encode()
+}
@@ -69,3 +69,13 @@ func (s RSA) GetRudderAnglePortside() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the RSA sentence into NMEA 0183 text
+func (s RSA) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.RudderAngleStarboard)
+	e.StringField(s.StatusStarboard)
+	e.Float64(s.RudderAnglePortside)
+	e.StringField(s.StatusPortside)
+	return e.Encode()
+}
@@ -34,3 +34,14 @@ func newRTE(s BaseSentence) (RTE, error) {
 		Idents:                    p.ListString(4, "ident of waypoints"),
 	}, p.Err()
 }
+
+// Encode serializes the RTE sentence into NMEA 0183 text
+func (s RTE) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Int64(s.NumberOfSentences)
+	e.Int64(s.SentenceNumber)
+	e.StringField(s.ActiveRouteOrWaypointList)
+	e.StringField(s.Name)
+	e.ListString(s.Idents)
+	return e.Encode()
+}
@@ -0,0 +1,224 @@
+package nmea
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// DefaultMaxFrameLength is the default maximum number of bytes of a frame (tag block and sentence) read by the Scanner
+	DefaultMaxFrameLength = 512
+
+	tagBlockSep = '\\'
+)
+
+// Frame is a single sentence read by the Scanner
+type Frame struct {
+	Sentence     Sentence     // The parsed sentence, nil if the sentence could not be parsed
+	BaseSentence BaseSentence // The base sentence, empty if the raw data could not be split into fields
+	Err          error        // The error that occurred while parsing the frame
+	Raw          string       // The raw data of the frame, including the tag block
+	Offset       int64        // The byte offset of the start of the frame in the stream
+}
+
+// Scanner reads sentences from an io.Reader. Sentences may be terminated by CR, LF or CRLF or may
+// follow each other without terminators. Data that is not part of a sentence is skipped.
+type Scanner struct {
+	// MaxFrameLength is the maximum number of bytes of a frame, longer frames are
+	// reported as an error and skipped. DefaultMaxFrameLength is used when it is 0 or less.
+	MaxFrameLength int
+
+	r       *bufio.Reader
+	config  ParseConfig
+	offset  int64
+	pending []byte // bytes read that belong to the next frame
+	frame   Frame
+	err     error
+}
+
+// NewScanner constructor, the options are passed to the parser, see Parse for the supported options.
+// The scanner does not scan and Err returns ErrUnknownOption when an option is not supported.
+func NewScanner(r io.Reader, options ...string) *Scanner {
+	config, err := parseOptions(options)
+	s := NewScannerWithConfig(r, config)
+	s.err = err
+	return s
+}
+
+// NewScannerWithConfig constructor, the config is passed to the parser, see ParseWithConfig
+func NewScannerWithConfig(r io.Reader, config ParseConfig) *Scanner {
+	return &Scanner{
+		r:      bufio.NewReader(r),
+		config: config,
+	}
+}
+
+// Scan advances the scanner to the next frame, which is then available through the Frame method.
+// It returns false when the end of the input is reached or an error occurs while reading.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	raw, offset, err := s.next()
+	if raw == nil {
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		return false
+	}
+	s.frame = s.parse(string(raw), offset, err)
+	return true
+}
+
+// Frame returns the most recent frame read by a call to Scan.
+func (s *Scanner) Frame() Frame {
+	return s.frame
+}
+
+// Err returns the first non-EOF error that was encountered while reading.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+func (s *Scanner) parse(raw string, offset int64, err error) Frame {
+	frame := Frame{Raw: raw, Offset: offset, Err: err}
+	if err != nil {
+		return frame
+	}
+	frame.BaseSentence, frame.Err = parseSentence(raw, s.config)
+	if frame.Err != nil {
+		return frame
+	}
+	frame.Sentence, frame.Err = parseBaseSentence(frame.BaseSentence, s.config)
+	return frame
+}
+
+func (s *Scanner) maxFrameLength() int {
+	if s.MaxFrameLength <= 0 {
+		return DefaultMaxFrameLength
+	}
+	return s.MaxFrameLength
+}
+
+func (s *Scanner) errFrameTooLong() error {
+	return fmt.Errorf("nmea: frame exceeds the maximum length of %d bytes", s.maxFrameLength())
+}
+
+func (s *Scanner) readByte() (byte, error) {
+	if len(s.pending) > 0 {
+		b := s.pending[0]
+		s.pending = s.pending[1:]
+		return b, nil
+	}
+	b, err := s.r.ReadByte()
+	if err == nil {
+		s.offset++
+	}
+	return b, err
+}
+
+// unreadByte makes the byte the first byte of the next frame.
+func (s *Scanner) unreadByte(b byte) {
+	s.pending = append(s.pending, b)
+}
+
+func isFrameStart(b byte) bool {
+	return b == tagBlockSep || b == SentenceStart[0] || b == SentenceStartEncapsulated[0]
+}
+
+func isPrintable(b byte) bool {
+	return b >= 0x20 && b <= 0x7e
+}
+
+func isHex(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'A' && b <= 'F') || (b >= 'a' && b <= 'f')
+}
+
+// next returns the raw bytes of the next frame and the offset of its first byte. A nil slice
+// is returned when there are no more frames. An error is returned with a frame that has to be skipped.
+func (s *Scanner) next() ([]byte, int64, error) {
+	const (
+		inTagBlock = iota
+		afterTagBlock
+		inSentence
+		inChecksum
+	)
+	var (
+		frame    []byte
+		offset   int64
+		state    int
+		checksum int
+		tooLong  bool
+	)
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			if frame == nil {
+				return nil, 0, err
+			}
+			if tooLong {
+				return frame, offset, s.errFrameTooLong()
+			}
+			return frame, offset, nil
+		}
+		if frame == nil {
+			// skip everything until the start of a frame
+			if isFrameStart(b) {
+				frame = []byte{b}
+				offset = s.offset - int64(len(s.pending)) - 1
+				state = inSentence
+				if b == tagBlockSep {
+					state = inTagBlock
+				}
+			}
+			continue
+		}
+		if tooLong {
+			// skip the rest of the frame
+			if b == '\r' || b == '\n' || isFrameStart(b) {
+				s.unreadByte(b)
+				return frame, offset, s.errFrameTooLong()
+			}
+			continue
+		}
+		if !isPrintable(b) {
+			// a line terminator or binary noise ends the frame
+			return frame, offset, nil
+		}
+		switch state {
+		case inTagBlock:
+			if b == tagBlockSep {
+				state = afterTagBlock
+			}
+		case afterTagBlock:
+			state = inSentence
+			if b == tagBlockSep {
+				state = inTagBlock
+			}
+		case inSentence:
+			if isFrameStart(b) {
+				s.unreadByte(b)
+				return frame, offset, nil
+			}
+			if b == ChecksumSep[0] {
+				state = inChecksum
+			}
+		case inChecksum:
+			if !isHex(b) {
+				s.unreadByte(b)
+				return frame, offset, nil
+			}
+			checksum++
+		}
+		frame = append(frame, b)
+		if len(frame) > s.maxFrameLength() {
+			tooLong = true
+			continue
+		}
+		if state == inChecksum && checksum == 2 {
+			return frame, offset, nil
+		}
+	}
+}
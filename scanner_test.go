@@ -0,0 +1,166 @@
+package nmea_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+var _ = Describe("Scanner", func() {
+	var (
+		input   string
+		scanner *Scanner
+		frames  []Frame
+	)
+	JustBeforeEach(func() {
+		scanner = NewScanner(strings.NewReader(input))
+		frames = nil
+		for scanner.Scan() {
+			frames = append(frames, scanner.Frame())
+		}
+	})
+	Context("with sentences terminated by CR, LF and CRLF", func() {
+		BeforeEach(func() {
+			input = "$GPHDT,123.456,T*32\r$GPROT,3.1,A*33\n$GPHEV,-0.07*54\r\n"
+		})
+		It("returns all sentences", func() {
+			Expect(scanner.Err()).ToNot(HaveOccurred())
+			Expect(frames).To(HaveLen(3))
+			Expect(frames[0].Sentence).To(BeAssignableToTypeOf(HDT{}))
+			Expect(frames[1].Sentence).To(BeAssignableToTypeOf(ROT{}))
+			Expect(frames[2].Sentence).To(BeAssignableToTypeOf(HEV{}))
+		})
+		It("returns the offsets of the frames", func() {
+			Expect(frames[0].Offset).To(Equal(int64(0)))
+			Expect(frames[1].Offset).To(Equal(int64(20)))
+			Expect(frames[2].Offset).To(Equal(int64(36)))
+		})
+	})
+	Context("with leading garbage and binary noise between sentences", func() {
+		BeforeEach(func() {
+			input = "garbage\x00\xff$GPHDT,123.456,T*32\x01\x02\x03noise\r\n$GPROT,3.1,A*33"
+		})
+		It("skips the garbage", func() {
+			Expect(frames).To(HaveLen(2))
+			Expect(frames[0].Err).ToNot(HaveOccurred())
+			Expect(frames[0].Raw).To(Equal("$GPHDT,123.456,T*32"))
+			Expect(frames[0].Offset).To(Equal(int64(9)))
+			Expect(frames[1].Err).ToNot(HaveOccurred())
+			Expect(frames[1].Raw).To(Equal("$GPROT,3.1,A*33"))
+		})
+	})
+	Context("with sentences glued together without terminators", func() {
+		BeforeEach(func() {
+			input = "$GPHDT,123.456,T*32$GPROT,3.1,A*33!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52"
+		})
+		It("splits the sentences", func() {
+			Expect(frames).To(HaveLen(3))
+			Expect(frames[0].Raw).To(Equal("$GPHDT,123.456,T*32"))
+			Expect(frames[1].Raw).To(Equal("$GPROT,3.1,A*33"))
+			Expect(frames[1].Offset).To(Equal(int64(19)))
+			Expect(frames[2].Sentence).To(BeAssignableToTypeOf(VDMVDO{}))
+		})
+	})
+	Context("with a truncated sentence followed by a complete sentence", func() {
+		BeforeEach(func() {
+			input = "$GPHDT,123.4$GPROT,3.1,A*33\r\n"
+		})
+		It("returns an error for the truncated sentence and resynchronises", func() {
+			Expect(frames).To(HaveLen(2))
+			Expect(frames[0].Err).To(MatchError("nmea: sentence does not contain checksum separator"))
+			Expect(frames[0].Sentence).To(BeNil())
+			Expect(frames[1].Err).ToNot(HaveOccurred())
+			Expect(frames[1].BaseSentence.Prefix()).To(Equal("GPROT"))
+		})
+	})
+	Context("with a tag block", func() {
+		BeforeEach(func() {
+			input = "\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52\r\n"
+		})
+		It("returns the sentence with the tag block", func() {
+			Expect(frames).To(HaveLen(1))
+			Expect(frames[0].Err).ToNot(HaveOccurred())
+			Expect(frames[0].BaseSentence.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+		})
+	})
+	Context("with multiple tag blocks", func() {
+		BeforeEach(func() {
+			input = "\\g:1-2-73874*61\\\\s:r003669945,c:1241544035*79\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52\r\n"
+		})
+		It("returns the sentence with the combined tag block", func() {
+			Expect(frames).To(HaveLen(1))
+			Expect(frames[0].Err).ToNot(HaveOccurred())
+			Expect(frames[0].BaseSentence.TagBlock.Group).To(Equal(NewGroup(1, 2, 73874)))
+			Expect(frames[0].BaseSentence.TagBlock.Source).To(Equal(NewString("r003669945")))
+		})
+	})
+	Context("with an over-long line", func() {
+		BeforeEach(func() {
+			input = "$GPTXT," + strings.Repeat("A", 600) + "*00\r\n$GPHDT,123.456,T*32\r\n"
+		})
+		It("returns an error for the over-long line and continues", func() {
+			Expect(frames).To(HaveLen(2))
+			Expect(frames[0].Err).To(MatchError("nmea: frame exceeds the maximum length of 512 bytes"))
+			Expect(frames[1].Err).ToNot(HaveOccurred())
+			Expect(frames[1].Sentence).To(BeAssignableToTypeOf(HDT{}))
+		})
+	})
+	Context("with an over-long line followed by a tag block", func() {
+		BeforeEach(func() {
+			input = "$GPTXT," + strings.Repeat("A", 600) + "\\s:Satellite_1,c:1553390539*0E\\$GPHDT,123.456,T*32\r\n"
+		})
+		It("keeps the tag block of the next sentence", func() {
+			Expect(frames).To(HaveLen(2))
+			Expect(frames[0].Err).To(MatchError("nmea: frame exceeds the maximum length of 512 bytes"))
+			Expect(frames[1].Err).ToNot(HaveOccurred())
+			Expect(frames[1].BaseSentence.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+		})
+	})
+	Context("with a sentence without a terminator at the end of the input", func() {
+		BeforeEach(func() {
+			input = "$GPHDT,123.456,T"
+		})
+		It("returns the sentence", func() {
+			Expect(frames).To(HaveLen(1))
+			Expect(frames[0].Raw).To(Equal("$GPHDT,123.456,T"))
+			Expect(frames[0].Err).To(MatchError("nmea: sentence does not contain checksum separator"))
+		})
+	})
+	Context("when the reader fails", func() {
+		It("returns the error", func() {
+			scanner := NewScanner(failingReader{})
+			Expect(scanner.Scan()).To(BeFalse())
+			Expect(scanner.Err()).To(MatchError("read failed"))
+		})
+	})
+	Context("when a config is given", func() {
+		It("passes the config to the parser", func() {
+			scanner := NewScannerWithConfig(strings.NewReader("$GPHDT,123.456,T*32\r\n$HEHDT,123.456,T*28\r\n"), ParseConfig{AllowedTalkers: []string{"HE"}})
+			Expect(scanner.Scan()).To(BeTrue())
+			Expect(scanner.Frame().Err).To(MatchError(ErrTalkerNotAllowed))
+			Expect(scanner.Scan()).To(BeTrue())
+			Expect(scanner.Frame().Err).ToNot(HaveOccurred())
+		})
+	})
+	Context("when options are given", func() {
+		It("passes the options to the parser", func() {
+			scanner := NewScanner(strings.NewReader("$GPHDT,123.456,T*FF\r\n"), "AllowChecksumMismatch")
+			Expect(scanner.Scan()).To(BeTrue())
+			Expect(scanner.Frame().Err).ToNot(HaveOccurred())
+		})
+		It("returns an error for an unknown option", func() {
+			scanner := NewScanner(strings.NewReader("$GPHDT,123.456,T*32\r\n"), "Unknown")
+			Expect(scanner.Scan()).To(BeFalse())
+			Expect(scanner.Err()).To(MatchError(ErrUnknownOption))
+		})
+	})
+})
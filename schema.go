@@ -0,0 +1,475 @@
+package nmea
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Units of the fields in the schemas
+const (
+	UnitDegrees             = "degrees"
+	UnitDegreesPerMinute    = "degrees per minute"
+	UnitDegreesCelsius      = "degrees Celsius"
+	UnitDegreesFahrenheit   = "degrees Fahrenheit"
+	UnitKelvin              = "kelvin"
+	UnitKnots               = "knots"
+	UnitKilometersPerHour   = "kilometers per hour"
+	UnitMetersPerSecond     = "meters per second"
+	UnitMilesPerHour        = "miles per hour"
+	UnitMeters              = "meters"
+	UnitFeet                = "feet"
+	UnitFathoms             = "fathoms"
+	UnitBar                 = "bar"
+	UnitInchesOfMercury     = "inches of mercury"
+	UnitPascal              = "pascal"
+	UnitPercent             = "percent"
+	UnitHours               = "hours"
+	UnitMinutes             = "minutes"
+	UnitSeconds             = "seconds"
+	UnitBits                = "bits"
+	UnitDilutionOfPrecision = "dilution of precision"
+	UnitSatellites          = "satellites"
+)
+
+// FieldSchema describes a field of a sentence type
+type FieldSchema struct {
+	Name        string // The name of the struct field, e.g. AirTemperature
+	Index       int    // The index of the first NMEA field after the address field, -1 if the value is not read from a field
+	Unit        string // The unit of the value, empty if the value has no unit
+	Type        string // The Go type of the struct field, e.g. Float64
+	Description string // The description of the field
+}
+
+// SentenceSchema describes the fields of a sentence type in the order of the sentence
+type SentenceSchema struct {
+	Type        string        // The data type, e.g. MDA
+	Description string        // The description of the sentence type
+	Fields      []FieldSchema // The fields of the sentence type
+}
+
+// Field returns the schema of the field with the name
+func (s SentenceSchema) Field(name string) (FieldSchema, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FieldSchema{}, false
+}
+
+// valueTypeNames replaces the instances of Value with the names of their aliases
+var valueTypeNames = strings.NewReplacer(
+	"nmea.Value[float64]", "nmea.Float64",
+	"nmea.Value[int64]", "nmea.Int64",
+	"nmea.Value[string]", "nmea.String",
+)
+
+// typeName returns the name of the type with the aliases of Value, e.g. nmea.Float64
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return valueTypeNames.Replace(t.String())
+}
+
+type schemaEntry struct {
+	schema SentenceSchema
+	goType reflect.Type
+}
+
+// fieldMetadata contains the index, unit and description of the fields of a sentence type by the
+// name of the field, the name and type of the fields are taken from the struct
+type fieldMetadata map[string]FieldSchema
+
+// RegisterSchema adds the schema of a sentence type to the default registry, see Registry.RegisterSchema
+func RegisterSchema(prototype Sentence, schema SentenceSchema) error {
+	return defaultRegistry.RegisterSchema(prototype, schema)
+}
+
+// MustRegisterSchema adds the schema of a sentence type to the default registry or panics, see RegisterSchema
+func MustRegisterSchema(prototype Sentence, schema SentenceSchema) {
+	if err := RegisterSchema(prototype, schema); err != nil {
+		panic(err)
+	}
+}
+
+// Schema returns the schema of the sentence type of the default registry
+func Schema(sentenceType string) (SentenceSchema, bool) {
+	return defaultRegistry.Schema(sentenceType)
+}
+
+// SchemaOf returns the schema of the sentence of the default registry, see Registry.SchemaOf
+func SchemaOf(s Sentence) (SentenceSchema, bool) {
+	return defaultRegistry.SchemaOf(s)
+}
+
+// Schemas returns the schemas of all sentence types of the default registry sorted by type
+func Schemas() []SentenceSchema {
+	return defaultRegistry.Schemas()
+}
+
+// RegisterSchema adds the schema of a sentence type, the prototype is a value of the struct that is
+// returned by the parser of the sentence type. The fields of the schema are the exported fields of
+// the prototype in the order of the struct, the Name and Type of the fields are taken from the
+// prototype. The Fields of the schema contain the Index, Unit and Description of the fields by
+// Name, a field of the prototype without an entry gets Index -1.
+func (r *Registry) RegisterSchema(prototype Sentence, schema SentenceSchema) error {
+	fields := make(fieldMetadata, len(schema.Fields))
+	for _, f := range schema.Fields {
+		fields[f.Name] = f
+	}
+	return r.registerSchema(prototype, schema.Type, schema.Description, fields)
+}
+
+func (r *Registry) registerSchema(prototype Sentence, sentenceType string, description string, metadata fieldMetadata) error {
+	if sentenceType == "" {
+		return fmt.Errorf("nmea: schema requires a sentence type")
+	}
+	goType := reflect.TypeOf(prototype)
+	if goType == nil || goType.Kind() != reflect.Struct {
+		return fmt.Errorf("nmea: schema for sentence type '%s' requires a struct prototype", sentenceType)
+	}
+	var fields []FieldSchema
+	for _, field := range sentenceFields(goType) {
+		f, ok := metadata[field.Name]
+		if !ok {
+			f.Index = -1
+		}
+		f.Name = field.Name
+		f.Type = strings.ReplaceAll(typeName(field.Type), "nmea.", "")
+		fields = append(fields, f)
+	}
+	for name := range metadata {
+		if _, ok := goType.FieldByName(name); !ok {
+			return fmt.Errorf("nmea: %s has no field '%s'", goType, name)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.schemas[sentenceType]; ok {
+		return fmt.Errorf("nmea: schema for sentence type '%s' already exists", sentenceType)
+	}
+	if r.schemas == nil {
+		r.schemas = map[string]schemaEntry{}
+	}
+	r.schemas[sentenceType] = schemaEntry{
+		schema: SentenceSchema{Type: sentenceType, Description: description, Fields: fields},
+		goType: goType,
+	}
+	return nil
+}
+
+// Schema returns the schema of the sentence type
+func (r *Registry) Schema(sentenceType string) (SentenceSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.schemas[sentenceType]
+	return entry.schema, ok
+}
+
+// SchemaOf returns the schema of the sentence, the schema is looked up by the data type of the
+// sentence and by the struct of the sentence for types that share a struct, such as MTK
+func (r *Registry) SchemaOf(s Sentence) (SentenceSchema, bool) {
+	goType := reflect.TypeOf(s)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if entry, ok := r.schemas[s.DataType()]; ok && entry.goType == goType {
+		return entry.schema, true
+	}
+	for _, entry := range r.schemas {
+		if entry.goType == goType {
+			return entry.schema, true
+		}
+	}
+	return SentenceSchema{}, false
+}
+
+// Schemas returns the schemas of all sentence types sorted by type
+func (r *Registry) Schemas() []SentenceSchema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]SentenceSchema, 0, len(r.schemas))
+	for _, entry := range r.schemas {
+		result = append(result, entry.schema)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result
+}
+
+// sentenceFields returns the exported fields of the struct in the order of the struct, the embedded
+// structs that contain the BaseSentence, e.g. BaseSentence and Proprietary, are skipped
+func sentenceFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || (field.Anonymous && embedsBaseSentence(field.Type)) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// embedsBaseSentence checks if the type is the BaseSentence or a struct that embeds it
+func embedsBaseSentence(t reflect.Type) bool {
+	if t == reflect.TypeOf(BaseSentence{}) {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && embedsBaseSentence(field.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldValue returns the value of the field with the name, e.g. FieldValue(s, "AirTemperature")
+// returns the Float64 of the air temperature of an MDA sentence
+func FieldValue(s Sentence, name string) (interface{}, error) {
+	v := reflect.ValueOf(s)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nmea: %T is not a struct", s)
+	}
+	field, ok := v.Type().FieldByName(name)
+	if !ok || !field.IsExported() {
+		return nil, fmt.Errorf("nmea: %s has no field '%s'", v.Type(), name)
+	}
+	return v.FieldByIndex(field.Index).Interface(), nil
+}
+
+// FieldValueAs returns the value of the field with the name as the type T, see FieldValue
+func FieldValueAs[T any](s Sentence, name string) (T, error) {
+	var result T
+	value, err := FieldValue(s, name)
+	if err != nil {
+		return result, err
+	}
+	result, ok := value.(T)
+	if !ok {
+		return result, fmt.Errorf("nmea: field '%s' of %T is a %s, not a %s", name, s, typeName(reflect.TypeOf(value)), typeName(reflect.TypeOf(&result).Elem()))
+	}
+	return result, nil
+}
+
+// registerSchemas adds the schemas of the built-in types to the registry
+func registerSchemas(r *Registry) {
+	latitude := FieldSchema{Unit: UnitDegrees, Description: "Latitude, negative on the southern hemisphere"}
+	longitude := FieldSchema{Unit: UnitDegrees, Description: "Longitude, negative on the western hemisphere"}
+	at := func(f FieldSchema, index int) FieldSchema {
+		f.Index = index
+		return f
+	}
+	vdmvdo := fieldMetadata{
+		"NumFragments":   {Index: 0, Description: "Number of fragments of the message"},
+		"FragmentNumber": {Index: 1, Description: "Fragment number of this sentence"},
+		"MessageID":      {Index: 2, Description: "Sequential message ID of multi-fragment messages"},
+		"Channel":        {Index: 3, Description: "Radio channel, A or B"},
+		"Payload":        {Index: 4, Unit: UnitBits, Description: "Decoded 6-bit armoured payload, one bit per byte"},
+		"Fragments":      {Index: -1, Description: "All fragments of the message, set by the AISAssembler"},
+		"Packet":         {Index: -1, Description: "Decoded AIS message, set on the fragment that completes a multi-fragment message"},
+	}
+	for _, s := range []struct {
+		prototype   Sentence
+		typ         string
+		description string
+		fields      fieldMetadata
+	}{
+		{RMC{}, TypeRMC, "Recommended minimum specific GNSS data", fieldMetadata{
+			"Time":      {Index: 0, Description: "UTC time of the position fix"},
+			"Validity":  {Index: 1, Description: "Status, A = valid, V = invalid"},
+			"Latitude":  at(latitude, 2),
+			"Longitude": at(longitude, 4),
+			"Speed":     {Index: 6, Unit: UnitKnots, Description: "Speed over ground"},
+			"Course":    {Index: 7, Unit: UnitDegrees, Description: "Course over ground, true"},
+			"Date":      {Index: 8, Description: "UTC date of the position fix"},
+			"Variation": {Index: 9, Unit: UnitDegrees, Description: "Magnetic variation, negative when west"},
+			"FAAMode":   {Index: 11, Description: "FAA mode indicator, A = autonomous, D = differential, E = estimated, N = not valid"},
+			"NavStatus": {Index: 12, Description: "Navigational status, S = safe, C = caution, U = unsafe, V = not valid"},
+		}},
+		{GGA{}, TypeGGA, "Global positioning system fix data", fieldMetadata{
+			"Time":          {Index: 0, Description: "UTC time of the position fix"},
+			"Latitude":      at(latitude, 1),
+			"Longitude":     at(longitude, 3),
+			"FixQuality":    {Index: 5, Description: "Quality of the fix"},
+			"NumSatellites": {Index: 6, Unit: UnitSatellites, Description: "Number of satellites in use"},
+			"HDOP":          {Index: 7, Unit: UnitDilutionOfPrecision, Description: "Horizontal dilution of precision"},
+			"Altitude":      {Index: 8, Unit: UnitMeters, Description: "Altitude of the antenna above mean sea level"},
+			"Separation":    {Index: 10, Unit: UnitMeters, Description: "Geoidal separation"},
+			"DGPSAge":       {Index: 12, Unit: UnitSeconds, Description: "Age of the differential GPS data"},
+			"DGPSId":        {Index: 13, Description: "Differential reference station ID"},
+		}},
+		{GSA{}, TypeGSA, "GNSS DOP and active satellites", fieldMetadata{
+			"Mode":    {Index: 0, Description: "Selection mode, A = automatic, M = manual"},
+			"FixType": {Index: 1, Description: "Fix type, 1 = no fix, 2 = 2D, 3 = 3D"},
+			"SV":      {Index: 2, Description: "PRNs of the satellites used for the fix, fields 2 to 13"},
+			"PDOP":    {Index: 14, Unit: UnitDilutionOfPrecision, Description: "Position dilution of precision"},
+			"HDOP":    {Index: 15, Unit: UnitDilutionOfPrecision, Description: "Horizontal dilution of precision"},
+			"VDOP":    {Index: 16, Unit: UnitDilutionOfPrecision, Description: "Vertical dilution of precision"},
+		}},
+		{GLL{}, TypeGLL, "Geographic position, latitude and longitude", fieldMetadata{
+			"Latitude":  at(latitude, 0),
+			"Longitude": at(longitude, 2),
+			"Time":      {Index: 4, Description: "UTC time of the position"},
+			"Validity":  {Index: 5, Description: "Status, A = valid, V = invalid"},
+			"FAAMode":   {Index: 6, Description: "FAA mode indicator, A = autonomous, D = differential, E = estimated, N = not valid"},
+		}},
+		{VTG{}, TypeVTG, "Course over ground and ground speed", fieldMetadata{
+			"TrueTrack":        {Index: 0, Unit: UnitDegrees, Description: "Course over ground, true"},
+			"MagneticTrack":    {Index: 2, Unit: UnitDegrees, Description: "Course over ground, magnetic"},
+			"GroundSpeedKnots": {Index: 4, Unit: UnitKnots, Description: "Speed over ground"},
+			"GroundSpeedKPH":   {Index: 6, Unit: UnitKilometersPerHour, Description: "Speed over ground"},
+		}},
+		{ZDA{}, TypeZDA, "Time and date", fieldMetadata{
+			"Time":          {Index: 0, Description: "UTC time"},
+			"Day":           {Index: 1, Description: "Day of the month, 1 to 31"},
+			"Month":         {Index: 2, Description: "Month, 1 to 12"},
+			"Year":          {Index: 3, Description: "Year with four digits"},
+			"OffsetHours":   {Index: 4, Unit: UnitHours, Description: "Local zone hours, added to the local time to get UTC, negative east of Greenwich"},
+			"OffsetMinutes": {Index: 5, Unit: UnitMinutes, Description: "Local zone minutes, same sign as the hours"},
+		}},
+		{GSV{}, TypeGSV, "GNSS satellites in view", fieldMetadata{
+			"TotalMessages":   {Index: 0, Description: "Total number of GSV sentences in this cycle"},
+			"MessageNumber":   {Index: 1, Description: "Number of this sentence in the cycle"},
+			"NumberSVsInView": {Index: 2, Description: "Total number of satellites in view"},
+			"Info":            {Index: 3, Description: "PRN, elevation in degrees, azimuth in degrees and SNR in dB-Hz of up to 4 satellites, fields 3 to 18"},
+		}},
+		{HDT{}, TypeHDT, "Heading, true", fieldMetadata{
+			"Heading": {Index: 0, Unit: UnitDegrees, Description: "Heading"},
+			"True":    {Index: 1, Description: "Heading is relative to true north"},
+		}},
+		{GNS{}, TypeGNS, "GNSS fix data", fieldMetadata{
+			"Time":       {Index: 0, Description: "UTC time of the position fix"},
+			"Latitude":   at(latitude, 1),
+			"Longitude":  at(longitude, 3),
+			"Mode":       {Index: 5, Description: "Mode indicator per constellation"},
+			"SVs":        {Index: 6, Unit: UnitSatellites, Description: "Number of satellites in use"},
+			"HDOP":       {Index: 7, Unit: UnitDilutionOfPrecision, Description: "Horizontal dilution of precision"},
+			"Altitude":   {Index: 8, Unit: UnitMeters, Description: "Altitude of the antenna above mean sea level"},
+			"Separation": {Index: 9, Unit: UnitMeters, Description: "Geoidal separation"},
+			"Age":        {Index: 10, Unit: UnitSeconds, Description: "Age of the differential data"},
+			"Station":    {Index: 11, Description: "Differential reference station ID"},
+		}},
+		{THS{}, TypeTHS, "True heading and status", fieldMetadata{
+			"Heading": {Index: 0, Unit: UnitDegrees, Description: "Heading, true"},
+			"Status":  {Index: 1, Description: "Mode indicator, A = autonomous, E = estimated, M = manual, S = simulator, V = invalid"},
+		}},
+		{WPL{}, TypeWPL, "Waypoint location", fieldMetadata{
+			"Latitude":  at(latitude, 0),
+			"Longitude": at(longitude, 2),
+			"Ident":     {Index: 4, Description: "Waypoint identifier"},
+		}},
+		{RTE{}, TypeRTE, "Routes", fieldMetadata{
+			"NumberOfSentences":         {Index: 0, Description: "Total number of RTE sentences of the route"},
+			"SentenceNumber":            {Index: 1, Description: "Number of this sentence"},
+			"ActiveRouteOrWaypointList": {Index: 2, Description: "c = complete route, w = working route"},
+			"Name":                      {Index: 3, Description: "Name or number of the route"},
+			"Idents":                    {Index: 4, Description: "Identifiers of the waypoints, fields 4 and further"},
+		}},
+		{VHW{}, TypeVHW, "Water speed and heading", fieldMetadata{
+			"TrueHeading":            {Index: 0, Unit: UnitDegrees, Description: "Heading, true"},
+			"MagneticHeading":        {Index: 2, Unit: UnitDegrees, Description: "Heading, magnetic"},
+			"SpeedThroughWaterKnots": {Index: 4, Unit: UnitKnots, Description: "Speed through water"},
+			"SpeedThroughWaterKPH":   {Index: 6, Unit: UnitKilometersPerHour, Description: "Speed through water"},
+		}},
+		{DPT{}, TypeDPT, "Depth of water", fieldMetadata{
+			"Depth":      {Index: 0, Unit: UnitMeters, Description: "Water depth relative to the transducer"},
+			"Offset":     {Index: 1, Unit: UnitMeters, Description: "Offset from the transducer, positive to the water line, negative to the keel"},
+			"RangeScale": {Index: 2, Unit: UnitMeters, Description: "Maximum range scale in use"},
+		}},
+		{DBT{}, TypeDBT, "Depth below transducer", fieldMetadata{
+			"DepthFeet":    {Index: 0, Unit: UnitFeet, Description: "Water depth below the transducer"},
+			"DepthMeters":  {Index: 2, Unit: UnitMeters, Description: "Water depth below the transducer"},
+			"DepthFathoms": {Index: 4, Unit: UnitFathoms, Description: "Water depth below the transducer"},
+		}},
+		{DBS{}, TypeDBS, "Depth below surface", fieldMetadata{
+			"DepthFeet":    {Index: 0, Unit: UnitFeet, Description: "Water depth below the surface"},
+			"DepthMeters":  {Index: 2, Unit: UnitMeters, Description: "Water depth below the surface"},
+			"DepthFathoms": {Index: 4, Unit: UnitFathoms, Description: "Water depth below the surface"},
+		}},
+		{HEV{}, TypeHEV, "Heave", fieldMetadata{
+			"Heave": {Index: 0, Unit: UnitMeters, Description: "Heave, positive upwards"},
+		}},
+		{MDA{}, TypeMDA, "Meteorological composite", fieldMetadata{
+			"BarometricPressureInInchesOfMercury": {Index: 0, Unit: UnitInchesOfMercury, Description: "Barometric pressure"},
+			"BarometricPressureInBar":             {Index: 2, Unit: UnitBar, Description: "Barometric pressure"},
+			"AirTemperature":                      {Index: 4, Unit: UnitDegreesCelsius, Description: "Air temperature"},
+			"WaterTemperature":                    {Index: 6, Unit: UnitDegreesCelsius, Description: "Water temperature"},
+			"RelativeHumidity":                    {Index: 8, Unit: UnitPercent, Description: "Relative humidity"},
+			"DewPoint":                            {Index: 10, Unit: UnitDegreesCelsius, Description: "Dew point"},
+			"WindDirectionTrue":                   {Index: 12, Unit: UnitDegrees, Description: "Wind direction, true"},
+			"WindDirectionMagnetic":               {Index: 14, Unit: UnitDegrees, Description: "Wind direction, magnetic"},
+			"WindSpeedInKnots":                    {Index: 16, Unit: UnitKnots, Description: "Wind speed"},
+			"WindSpeedInMetersPerSecond":          {Index: 18, Unit: UnitMetersPerSecond, Description: "Wind speed"},
+		}},
+		{MWD{}, TypeMWD, "Wind direction and speed", fieldMetadata{
+			"WindDirectionTrue":          {Index: 0, Unit: UnitDegrees, Description: "Wind direction, true"},
+			"WindDirectionMagnetic":      {Index: 2, Unit: UnitDegrees, Description: "Wind direction, magnetic"},
+			"WindSpeedInKnots":           {Index: 4, Unit: UnitKnots, Description: "Wind speed"},
+			"WindSpeedInMetersPerSecond": {Index: 6, Unit: UnitMetersPerSecond, Description: "Wind speed"},
+		}},
+		{MWV{}, TypeMWV, "Wind speed and angle", fieldMetadata{
+			"Angle":     {Index: 0, Unit: UnitDegrees, Description: "Wind angle relative to the bow"},
+			"Reference": {Index: 1, Description: "Reference, R = relative, T = true"},
+			"WindSpeed": {Index: 2, Description: "Wind speed, the unit is K = km/h, N = knots, M = m/s or S = mph"},
+			"Status":    {Index: 4, Description: "Status, A = valid, V = invalid"},
+		}},
+		{ROT{}, TypeROT, "Rate of turn", fieldMetadata{
+			"RateOfTurn": {Index: 0, Unit: UnitDegreesPerMinute, Description: "Rate of turn, negative to port"},
+			"Status":     {Index: 1, Description: "Status, A = valid, V = invalid"},
+		}},
+		{RSA{}, TypeRSA, "Rudder sensor angle", fieldMetadata{
+			"RudderAngleStarboard": {Index: 0, Unit: UnitDegrees, Description: "Starboard or single rudder angle, negative to port"},
+			"StatusStarboard":      {Index: 1, Description: "Status, A = valid, V = invalid"},
+			"RudderAnglePortside":  {Index: 2, Unit: UnitDegrees, Description: "Port rudder angle, negative to port"},
+			"StatusPortside":       {Index: 3, Description: "Status, A = valid, V = invalid"},
+		}},
+		{VWR{}, TypeVWR, "Relative (apparent) wind speed and angle", fieldMetadata{
+			"Angle":                        {Index: 0, Unit: UnitDegrees, Description: "Wind angle relative to the bow"},
+			"LeftRightOfBow":               {Index: 1, Description: "Side of the bow, L = left, R = right"},
+			"WindSpeedInKnots":             {Index: 2, Unit: UnitKnots, Description: "Wind speed"},
+			"WindSpeedInMetersPerSecond":   {Index: 4, Unit: UnitMetersPerSecond, Description: "Wind speed"},
+			"WindSpeedInKilometersPerHour": {Index: 6, Unit: UnitKilometersPerHour, Description: "Wind speed"},
+		}},
+		{GST{}, TypeGST, "GNSS pseudorange error statistics", fieldMetadata{
+			"Time":                                 {Index: 0, Description: "UTC time of the position fix"},
+			"RMSPseudorangeResiduals":              {Index: 1, Unit: UnitMeters, Description: "RMS value of the standard deviation of the range inputs"},
+			"ErrorEllipseSemiMajorAxis1SigmaError": {Index: 2, Unit: UnitMeters, Description: "Standard deviation of the semi-major axis of the error ellipse"},
+			"ErrorEllipseSemiMinorAxis1SigmaError": {Index: 3, Unit: UnitMeters, Description: "Standard deviation of the semi-minor axis of the error ellipse"},
+			"ErrorEllipseOrientation":              {Index: 4, Unit: UnitDegrees, Description: "Orientation of the semi-major axis of the error ellipse, true"},
+			"Latitude1SigmaError":                  {Index: 5, Unit: UnitMeters, Description: "Standard deviation of the latitude error"},
+			"Longitude1SigmaError":                 {Index: 6, Unit: UnitMeters, Description: "Standard deviation of the longitude error"},
+			"Height1SigmaError":                    {Index: 7, Unit: UnitMeters, Description: "Standard deviation of the altitude error"},
+		}},
+		{ALR{}, TypeALR, "Set alarm state", fieldMetadata{
+			"Time":        {Index: 0, Description: "UTC time of the alarm condition change"},
+			"Identifier":  {Index: 1, Description: "Unique alarm number at the alarm source"},
+			"Condition":   {Index: 2, Description: "Alarm condition, A = threshold exceeded, V = not exceeded"},
+			"State":       {Index: 3, Description: "Acknowledge state, A = acknowledged, V = unacknowledged"},
+			"Description": {Index: 4, Description: "Alarm description text"},
+		}},
+		{PGRME{}, TypePGRME, "Estimated position error (Garmin proprietary sentence)", fieldMetadata{
+			"Horizontal": {Index: 0, Unit: UnitMeters, Description: "Estimated horizontal position error"},
+			"Vertical":   {Index: 2, Unit: UnitMeters, Description: "Estimated vertical position error"},
+			"Spherical":  {Index: 4, Unit: UnitMeters, Description: "Estimated spherical equivalent position error"},
+		}},
+		{MTK{}, TypeMTK, "MediaTek command acknowledgement (MTK proprietary sentence)", fieldMetadata{
+			"Cmd":  {Index: 0, Description: "Command that is acknowledged"},
+			"Flag": {Index: 1, Description: "Result, 0 = invalid, 1 = unsupported, 2 = failed, 3 = succeeded"},
+		}},
+		{VDMVDO{}, TypeVDM, "AIS VHF data-link message", vdmvdo},
+		{VDMVDO{}, TypeVDO, "AIS VHF data-link own-vessel report", vdmvdo},
+	} {
+		if err := r.registerSchema(s.prototype, s.typ, s.description, s.fields); err != nil {
+			panic(err)
+		}
+	}
+}
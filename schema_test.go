@@ -0,0 +1,139 @@
+package nmea_test
+
+import (
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type SCH struct {
+	BaseSentence
+	Value Float64
+	Extra Int64
+}
+
+var _ = Describe("Schema", func() {
+	Context("when getting the schema of a built-in type", func() {
+		It("returns the fields in the order of the sentence", func() {
+			schema, ok := Schema(TypeMDA)
+			Expect(ok).To(BeTrue())
+			Expect(schema.Type).To(Equal(TypeMDA))
+			Expect(schema.Fields).To(HaveLen(10))
+			Expect(schema.Fields[2]).To(Equal(FieldSchema{
+				Name:        "AirTemperature",
+				Index:       4,
+				Unit:        UnitDegreesCelsius,
+				Type:        "Temperature",
+				Description: "Air temperature",
+			}))
+			for i := 1; i < len(schema.Fields); i++ {
+				Expect(schema.Fields[i].Index).To(BeNumerically(">", schema.Fields[i-1].Index))
+			}
+		})
+		It("returns the type of the fields", func() {
+			schema, _ := Schema(TypeGSA)
+			field, ok := schema.Field("SV")
+			Expect(ok).To(BeTrue())
+			Expect(field).To(MatchFields(IgnoreExtras, Fields{"Type": Equal("[]String"), "Index": Equal(2)}))
+			schema, _ = Schema(TypeVDM)
+			field, ok = schema.Field("Packet")
+			Expect(ok).To(BeTrue())
+			Expect(field).To(MatchFields(IgnoreExtras, Fields{"Type": Equal("ais.Packet"), "Index": Equal(-1)}))
+		})
+		It("has a schema for every built-in type", func() {
+			for _, typ := range []string{
+				TypeRMC, TypeGGA, TypeGSA, TypeGLL, TypeVTG, TypeZDA, TypeGSV, TypeHDT, TypeGNS, TypeTHS,
+				TypeWPL, TypeRTE, TypeVHW, TypeDPT, TypeDBT, TypeDBS, TypeHEV, TypeMDA, TypeMWD, TypeMWV,
+				TypeROT, TypeRSA, TypeVWR, TypeGST, TypeALR, TypePGRME, TypeMTK, TypeVDM, TypeVDO,
+			} {
+				schema, ok := Schema(typ)
+				Expect(ok).To(BeTrue(), typ)
+				Expect(schema.Description).ToNot(BeEmpty(), typ)
+				Expect(schema.Fields).ToNot(BeEmpty(), typ)
+				for _, field := range schema.Fields {
+					Expect(field.Description).ToNot(BeEmpty(), typ+"."+field.Name)
+				}
+			}
+		})
+		It("skips the embedded base sentence", func() {
+			schema, _ := Schema(TypePGRME)
+			Expect(schema.Fields).To(HaveLen(3))
+			_, ok := schema.Field("Proprietary")
+			Expect(ok).To(BeFalse())
+		})
+		It("returns all schemas sorted by type", func() {
+			schemas := Schemas()
+			Expect(len(schemas)).To(BeNumerically(">=", 29))
+			for i := 1; i < len(schemas); i++ {
+				Expect(schemas[i].Type > schemas[i-1].Type).To(BeTrue())
+			}
+		})
+	})
+	Context("when getting the schema of a sentence", func() {
+		It("returns the schema of the data type", func() {
+			s, err := Parse("!AIVDO,1,1,,,B25N?<@00:<PTGV<0wFKwpUoP06,0*23")
+			Expect(err).ToNot(HaveOccurred())
+			schema, ok := SchemaOf(s)
+			Expect(ok).To(BeTrue())
+			Expect(schema.Type).To(Equal(TypeVDO))
+		})
+		It("returns the schema of the struct for types that share a struct", func() {
+			s, err := Parse("$PMTK001,604,3*32")
+			Expect(err).ToNot(HaveOccurred())
+			schema, ok := SchemaOf(s)
+			Expect(ok).To(BeTrue())
+			Expect(schema.Type).To(Equal(TypeMTK))
+		})
+	})
+	Context("when registering a schema", func() {
+		var registry *Registry
+		BeforeEach(func() {
+			registry = DefaultRegistry().Clone()
+		})
+		It("registers the schema of a custom type", func() {
+			Expect(registry.RegisterSchema(SCH{}, SentenceSchema{Type: "SCH", Description: "Schema test", Fields: []FieldSchema{
+				{Name: "Value", Index: 0, Unit: UnitMeters, Description: "Value"},
+			}})).To(Succeed())
+			schema, ok := registry.SchemaOf(SCH{BaseSentence: BaseSentence{Type: "SCH"}})
+			Expect(ok).To(BeTrue())
+			Expect(schema.Fields).To(Equal([]FieldSchema{
+				{Name: "Value", Index: 0, Unit: UnitMeters, Type: "Float64", Description: "Value"},
+				{Name: "Extra", Index: -1, Type: "Int64"},
+			}))
+			Expect(registry.RegisterSchema(SCH{}, SentenceSchema{Type: "SCH"})).To(MatchError("nmea: schema for sentence type 'SCH' already exists"))
+			_, ok = Schema("SCH")
+			Expect(ok).To(BeFalse())
+		})
+		It("keeps the built-in schemas", func() {
+			_, ok := registry.Schema(TypeMDA)
+			Expect(ok).To(BeTrue())
+		})
+		It("returns an error for an unknown field", func() {
+			Expect(registry.RegisterSchema(SCH{}, SentenceSchema{Type: "SCX", Fields: []FieldSchema{{Name: "Missing"}}})).To(MatchError("nmea: nmea_test.SCH has no field 'Missing'"))
+		})
+	})
+	Context("when getting a field by name", func() {
+		var s Sentence
+		BeforeEach(func() {
+			var err error
+			s, err = Parse("$WIMDA,30.1176,I,1.0199,B,44.0,C,12.7,C,78.9,,14.2,C,359.0,T,358.7,M,6.4,N,3.3,M*37")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("returns the value", func() {
+			Expect(FieldValue(s, "AirTemperature")).To(Equal(NewTemperature(44.0, TemperatureUnitCelsius)))
+			Expect(FieldValueAs[Float64](s, "RelativeHumidity")).To(Equal(NewFloat64(78.9)))
+		})
+		It("returns the fields of the base sentence", func() {
+			Expect(FieldValue(s, "Talker")).To(Equal("WI"))
+		})
+		It("returns an error for an unknown field", func() {
+			_, err := FieldValue(s, "Missing")
+			Expect(err).To(MatchError("nmea: nmea.MDA has no field 'Missing'"))
+		})
+		It("returns an error for the wrong type", func() {
+			_, err := FieldValueAs[Int64](s, "AirTemperature")
+			Expect(err).To(MatchError("nmea: field 'AirTemperature' of nmea.MDA is a nmea.Temperature, not a nmea.Int64"))
+		})
+	})
+})
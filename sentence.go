@@ -3,7 +3,6 @@ package nmea
 import (
 	"fmt"
 	"strings"
-	"sync"
 )
 
 const (
@@ -20,11 +19,6 @@ const (
 	ChecksumSep = "*"
 )
 
-var (
-	customParsersMu = &sync.Mutex{}
-	customParsers   = map[string]ParserFunc{}
-)
-
 // ParserFunc callback used to parse specific sentence variants
 type ParserFunc func(BaseSentence) (Sentence, error)
 
@@ -44,6 +38,13 @@ type BaseSentence struct {
 	Checksum string   // The Checksum
 	Raw      string   // The raw NMEA sentence received
 	TagBlock TagBlock // NMEA tagblock
+
+	Diagnostics *Diagnostics // All field errors, only collected with the "CollectFieldErrors" option
+
+	strictFieldCount     bool          // The parser checks the number of fields
+	aisAssembler         *AISAssembler // The assembler of the AIS fragments, the default assembler is used when it is nil
+	skipFragmentAssembly bool          // The parser does not assemble AIS fragments, the Pipeline assembles them in order
+	dateConfig           DateConfig    // The interpretation of the dates of the sentence
 }
 
 // Prefix returns the talker and type of message
@@ -64,25 +65,33 @@ func (s BaseSentence) TalkerID() string {
 // String formats the sentence into a string
 func (s BaseSentence) String() string { return s.Raw }
 
+// tagBlock returns the tag block of the sentence
+func (s BaseSentence) tagBlock() TagBlock { return s.TagBlock }
+
+// diagnostics returns the field errors of the sentence
+func (s BaseSentence) diagnostics() *Diagnostics { return s.Diagnostics }
+
+// Encode serializes the fields of the sentence into NMEA 0183 text
+func (s BaseSentence) Encode() (string, error) {
+	e := NewEncoder(s)
+	e.RawField(s.Fields...)
+	return e.Encode()
+}
+
 // parseSentence parses a raw message into it's fields
-func parseSentence(raw string, allowEmptyChecksum bool, allowChecksumMismatch bool) (BaseSentence, error) {
-	raw = strings.TrimSpace(raw)
-	tagBlockParts := strings.SplitN(raw, `\`, 3)
+func parseSentence(raw string, config ParseConfig) (BaseSentence, error) {
+	tagBlock, raw := parseTagBlocks(strings.TrimSpace(raw), config.AllowEmptyChecksum, config.AllowChecksumMismatch)
 
-	var tagBlock TagBlock
-	if len(tagBlockParts) == 3 {
-		tags := tagBlockParts[1]
-		raw = tagBlockParts[2]
-		tagBlock = parseTagBlock(tags)
+	if config.MaxLength > 0 && len(raw) > config.MaxLength {
+		return BaseSentence{}, fmt.Errorf("%w [%d > %d]", ErrSentenceTooLong, len(raw), config.MaxLength)
 	}
-
 	startIndex := strings.IndexAny(raw, SentenceStart+SentenceStartEncapsulated)
 	if startIndex != 0 {
-		return BaseSentence{}, fmt.Errorf("nmea: sentence does not start with a '$' or '!'")
+		return BaseSentence{}, ErrMissingStart
 	}
 	sumSepIndex := strings.Index(raw, ChecksumSep)
 	if sumSepIndex == -1 {
-		return BaseSentence{}, fmt.Errorf("nmea: sentence does not contain checksum separator")
+		return BaseSentence{}, ErrMissingChecksumSeparator
 	}
 	var (
 		fieldsRaw   = raw[startIndex+1 : sumSepIndex]
@@ -91,21 +100,34 @@ func parseSentence(raw string, allowEmptyChecksum bool, allowChecksumMismatch bo
 		checksum    = Checksum(fieldsRaw)
 	)
 	// Validate the checksum
-	if needsChecksumCheck(checksumRaw, allowEmptyChecksum, allowChecksumMismatch) {
+	if needsChecksumCheck(checksumRaw, config.AllowEmptyChecksum, config.AllowChecksumMismatch) {
 		if checksum != checksumRaw {
-			return BaseSentence{}, fmt.Errorf(
-				"nmea: sentence checksum mismatch [%s != %s]", checksum, checksumRaw)
+			return BaseSentence{}, &ChecksumError{Expected: checksum, Actual: checksumRaw}
 		}
 	}
-	talker, typ := parsePrefix(fields[0])
-	return BaseSentence{
-		Talker:   talker,
-		Type:     typ,
-		Fields:   fields[1:],
-		Checksum: checksumRaw,
-		Raw:      raw,
-		TagBlock: tagBlock,
-	}, nil
+	prefix := fields[0]
+	if config.CaseInsensitivePrefix {
+		prefix = strings.ToUpper(prefix)
+	}
+	talker, typ := parsePrefix(prefix)
+	if err := config.talkerAllowed(talker); err != nil {
+		return BaseSentence{}, err
+	}
+	s := BaseSentence{
+		Talker:           talker,
+		Type:             typ,
+		Fields:           fields[1:],
+		Checksum:         checksumRaw,
+		Raw:              raw,
+		TagBlock:         tagBlock,
+		strictFieldCount: config.StrictFieldCount,
+		aisAssembler:     config.AISAssembler,
+		dateConfig:       config.Date,
+	}
+	if config.CollectFieldErrors {
+		s.Diagnostics = &Diagnostics{}
+	}
+	return s, nil
 }
 
 func needsChecksumCheck(checksum string, allowEmptyCheckSum bool, allowChecksumMismatch bool) bool {
@@ -118,12 +140,10 @@ func needsChecksumCheck(checksum string, allowEmptyCheckSum bool, allowChecksumM
 	return true
 }
 
-// parsePrefix takes the first field and splits it into a talker id and data type.
+// parsePrefix takes the first field and splits it into a talker id and data type, the talker of
+// proprietary sentences is P and the data type starts with the manufacturer mnemonic.
 func parsePrefix(s string) (string, string) {
-	if strings.HasPrefix(s, "PMTK") {
-		return "PMTK", s[4:]
-	}
-	if strings.HasPrefix(s, "P") {
+	if strings.HasPrefix(s, ProprietaryStart) {
 		return "P", s[1:]
 	}
 	if len(s) < 2 {
@@ -132,6 +152,14 @@ func parsePrefix(s string) (string, string) {
 	return s[:2], s[2:]
 }
 
+// checksums contains the uppercase hex strings of all checksums so Checksum does not allocate
+var checksums = func() (c [256]string) {
+	for i := range c {
+		c[i] = fmt.Sprintf("%02X", i)
+	}
+	return c
+}()
+
 // Checksum xor all the bytes in a string an return it
 // as an uppercase hex string
 func Checksum(s string) string {
@@ -139,7 +167,7 @@ func Checksum(s string) string {
 	for i := 0; i < len(s); i++ {
 		checksum ^= s[i]
 	}
-	return fmt.Sprintf("%02X", checksum)
+	return checksums[checksum]
 }
 
 // MustRegisterParser register a custom parser or panic
@@ -149,113 +177,39 @@ func MustRegisterParser(sentenceType string, parser ParserFunc) {
 	}
 }
 
-// RegisterParser register a custom parser
+// RegisterParser register a custom parser in the default registry for both start delimiters,
+// a custom parser replaces the parser of a built-in type. Use a Registry for more control.
 func RegisterParser(sentenceType string, parser ParserFunc) error {
-	customParsersMu.Lock()
-	defer customParsersMu.Unlock()
-
-	if _, ok := customParsers[sentenceType]; ok {
-		return fmt.Errorf("nmea: parser for sentence type '%q' already exists", sentenceType)
-	}
-
-	customParsers[sentenceType] = parser
-	return nil
+	return defaultRegistry.registerType(sentenceType, parser)
 }
 
 // Parse parses the given string into the correct sentence type.
 // options can be passed as string, supported options are:
 // * "AllowEmptyChecksum"
 // * "AllowChecksumMismatch"
+// * "CollectFieldErrors", all field errors are collected in the Diagnostics of the sentence
+// * "UnknownPassthrough", sentences without a parser are returned as Unknown
+// The checksum options also apply to the checksums of the tag blocks.
+// ErrUnknownOption is returned for other options, use ParseWithConfig for all options.
 func Parse(raw string, options ...string) (Sentence, error) {
-	allowEmptyChecksum := false
-	allowChecksumMismatch := false
-	for _, option := range options {
-		if option == "AllowEmptyChecksum" {
-			allowEmptyChecksum = true
-		}
-		if option == "AllowChecksumMismatch" {
-			allowChecksumMismatch = true
-		}
-	}
-
-	s, err := parseSentence(raw, allowEmptyChecksum, allowChecksumMismatch)
+	config, err := parseOptions(options)
 	if err != nil {
 		return nil, err
 	}
+	return ParseWithConfig(raw, config)
+}
 
-	// Custom parser allow overriding of existing parsers
-	if parser, ok := customParsers[s.Type]; ok {
-		return parser(s)
+// parseBaseSentence parses the fields of the base sentence into the correct sentence type.
+func parseBaseSentence(s BaseSentence, config ParseConfig) (Sentence, error) {
+	registry := config.Registry
+	if registry == nil {
+		registry = defaultRegistry
 	}
-
-	if strings.HasPrefix(s.Raw, SentenceStart) {
-		// MTK message types share the same format
-		// so we return the same struct for all types.
-		switch s.Talker {
-		case TypeMTK:
-			return newMTK(s)
-		}
-
-		switch s.Type {
-		case TypeRMC:
-			return newRMC(s)
-		case TypeGGA:
-			return newGGA(s)
-		case TypeGSA:
-			return newGSA(s)
-		case TypeGLL:
-			return newGLL(s)
-		case TypeVTG:
-			return newVTG(s)
-		case TypeZDA:
-			return newZDA(s)
-		case TypePGRME:
-			return newPGRME(s)
-		case TypeGSV:
-			return newGSV(s)
-		case TypeHDT:
-			return newHDT(s)
-		case TypeGNS:
-			return newGNS(s)
-		case TypeTHS:
-			return newTHS(s)
-		case TypeWPL:
-			return newWPL(s)
-		case TypeRTE:
-			return newRTE(s)
-		case TypeVHW:
-			return newVHW(s)
-		case TypeDPT:
-			return newDPT(s)
-		case TypeDBT:
-			return newDBT(s)
-		case TypeDBS:
-			return newDBS(s)
-		case TypeHEV:
-			return newHEV(s)
-		case TypeMDA:
-			return newMDA(s)
-		case TypeMWD:
-			return newMWD(s)
-		case TypeMWV:
-			return newMWV(s)
-		case TypeROT:
-			return newROT(s)
-		case TypeRSA:
-			return newRSA(s)
-		case TypeVWR:
-			return newVWR(s)
-		case TypeGST:
-			return newGST(s)
-		case TypeALR:
-			return newALR(s)
-		}
+	if parser, ok := registry.Lookup(s); ok {
+		return parser(s)
 	}
-	if strings.HasPrefix(s.Raw, SentenceStartEncapsulated) {
-		switch s.Type {
-		case TypeVDM, TypeVDO:
-			return newVDMVDO(s)
-		}
+	if config.UnknownPassthrough {
+		return Unknown{BaseSentence: s}, nil
 	}
-	return nil, fmt.Errorf("nmea: sentence prefix '%s' not supported", s.Prefix())
+	return nil, &UnsupportedPrefixError{Prefix: s.Prefix()}
 }
@@ -2,6 +2,7 @@ package nmea_test
 
 import (
 	"errors"
+	"testing"
 
 	. "github.com/munnik/go-nmea"
 	. "github.com/onsi/ginkgo/v2"
@@ -123,9 +124,10 @@ var _ = Describe("Sentence", func() {
 		})
 		Context("when a standard sentence is given with a valid TAG block", func() {
 			It("returns a valid value", func() {
-				result, err := Parse("\\s:Satellite_1,c:1553390539*62\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				result, err := Parse("\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
 				Expect(result).ToNot(BeNil())
 				Expect(err).ToNot(HaveOccurred())
+				Expect(result.(VDMVDO).TagBlock.Valid).To(BeTrue())
 			})
 		})
 		Context("when a standard sentence is given with a bad checksum", func() {
@@ -158,17 +160,29 @@ var _ = Describe("Sentence", func() {
 		})
 		Context("when a standard sentence is given without a TAG Block start delimiter", func() {
 			It("returns an error", func() {
-				result, err := Parse("s:Satellite_1,c:1553390539*62\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				result, err := Parse("s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
 				Expect(result).To(BeNil())
 				Expect(err).To(MatchError("nmea: sentence does not start with a '$' or '!'"))
 			})
 		})
 		Context("when a standard sentence is given without a TAG Block end delimiter", func() {
 			It("returns an error", func() {
-				result, err := Parse("\\s:Satellite_1,c:1553390539*62!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				result, err := Parse("\\s:Satellite_1,c:1553390539*0E!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
 				Expect(result).To(BeNil())
 				Expect(err).To(MatchError("nmea: sentence does not start with a '$' or '!'"))
 			})
 		})
 	})
+	Describe("Testing the Checksum function", func() {
+		It("returns two uppercase hex digits", func() {
+			Expect(Checksum("GPHDT,123.456,T")).To(Equal("32"))
+			Expect(Checksum("")).To(Equal("00"))
+			Expect(Checksum("\xff")).To(Equal("FF"))
+		})
+		It("does not allocate", func() {
+			Expect(testing.AllocsPerRun(100, func() {
+				Checksum("GPHDT,123.456,T")
+			})).To(BeZero())
+		})
+	})
 })
@@ -47,6 +47,46 @@ type NumberOfSatellites interface {
 	GetNumberOfSatellites() (int64, error)
 }
 
+// HorizontalDilution retrieves the horizontal dilution of precision from the sentence
+type HorizontalDilution interface {
+	GetHorizontalDilution() (float64, error)
+}
+
+// PositionDilution retrieves the position (3D) dilution of precision from the sentence
+type PositionDilution interface {
+	GetPositionDilution() (float64, error)
+}
+
+// VerticalDilution retrieves the vertical dilution of precision from the sentence
+type VerticalDilution interface {
+	GetVerticalDilution() (float64, error)
+}
+
+// HorizontalAccuracy retrieves the estimated horizontal position error in meters from the sentence
+type HorizontalAccuracy interface {
+	GetHorizontalAccuracy() (float64, error)
+}
+
+// VerticalAccuracy retrieves the estimated vertical position error in meters from the sentence
+type VerticalAccuracy interface {
+	GetVerticalAccuracy() (float64, error)
+}
+
+// AntennaAltitude retrieves the altitude of the antenna above mean sea level in meters from the sentence
+type AntennaAltitude interface {
+	GetAntennaAltitude() (float64, error)
+}
+
+// GeoidalSeparation retrieves the difference between the WGS-84 ellipsoid and mean sea level in meters from the sentence
+type GeoidalSeparation interface {
+	GetGeoidalSeparation() (float64, error)
+}
+
+// DifferentialAge retrieves the age of the differential corrections in seconds from the sentence
+type DifferentialAge interface {
+	GetDifferentialAge() (float64, error)
+}
+
 // Position2D retrieves the 2D position from the sentence
 type Position2D interface {
 	GetPosition2D() (float64, float64, error)
@@ -92,6 +132,11 @@ type TrueWindDirection interface {
 	GetTrueWindDirection() (float64, error)
 }
 
+// TrueWindAngle retrieves the true wind angle relative to the bow from the sentence
+type TrueWindAngle interface {
+	GetTrueWindAngle() (float64, error)
+}
+
 // MagneticWindDirection retrieves the magnetic wind direction from the sentence
 type MagneticWindDirection interface {
 	GetMagneticWindDirection() (float64, error)
@@ -117,6 +162,11 @@ type DewPointTemperature interface {
 	GetDewPointTemperature() (float64, error)
 }
 
+// OutsidePressure retrieves the outside air pressure from the sentence
+type OutsidePressure interface {
+	GetOutsidePressure() (float64, error)
+}
+
 // Humidity retrieves the relative humidity from the sentence
 type Humidity interface {
 	GetHumidity() (float64, error)
@@ -132,6 +182,16 @@ type DateTime interface {
 	GetDateTime() (string, error)
 }
 
+// Timestamp retrieves the date and time in UTC from the sentence
+type Timestamp interface {
+	GetTimestamp() (time.Time, error)
+}
+
+// TimeOfDay retrieves the UTC time since midnight from the sentence
+type TimeOfDay interface {
+	GetTimeOfDay() (time.Duration, error)
+}
+
 // CallSign retrieves the call sign of the vessel from the sentence
 type CallSign interface {
 	GetCallSign() (string, error)
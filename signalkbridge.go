@@ -0,0 +1,434 @@
+package nmea
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// DefaultSignalKBridgeTalker is the default talker of the sentences of the SignalKBridge,
+	// II is the talker of integrated instrumentation
+	DefaultSignalKBridgeTalker = "II"
+
+	// signalKBridgeDecimals is the number of decimals of the converted values
+	signalKBridgeDecimals = 4
+)
+
+// SignalKBridgeConfig contains the options of a SignalKBridge
+type SignalKBridgeConfig struct {
+	// Talker is the talker of the sentences, DefaultSignalKBridgeTalker when it is empty
+	Talker string
+	// Interval is the minimum time between two sentences of the same kind, e.g. two MWV sentences
+	// with the apparent wind, a sentence is created for every delta that updates one of its values
+	// when it is 0 or less
+	Interval time.Duration
+	// Self is the context of the own vessel, e.g. vessels.urn:mrn:imo:mmsi:244123456. Only the
+	// deltas of the own vessel are converted, a delta without context and vessels.self are
+	// always of the own vessel.
+	Self string
+}
+
+// SignalKBridge converts Signal K delta messages of the own vessel to sentences, it is the reverse
+// of ToSignalKDelta and uses the same paths. The bridge keeps the latest value of each path, so a
+// sentence can combine the values of several deltas, e.g. the position and the speed over ground
+// of a RMC sentence. A sentence is created when a delta updates one of the values that trigger it.
+// A SignalKBridge is safe for concurrent use by multiple goroutines.
+type SignalKBridge struct {
+	config    SignalKBridgeConfig
+	mu        sync.Mutex
+	values    map[string]interface{}
+	timestamp time.Time
+	sent      map[int]time.Time // The time of the latest sentence by index in signalKSentences
+}
+
+// signalKSentence creates a sentence from the latest values of the bridge, triggers are the paths
+// that cause the sentence to be created when they are updated
+type signalKSentence struct {
+	typ      string
+	triggers []string
+	create   func(b *SignalKBridge, base BaseSentence) (Sentence, bool)
+}
+
+// signalKSentences are the sentences of the bridge in the order they are created
+var signalKSentences = []signalKSentence{
+	{TypeRMC, []string{"navigation.position"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		latitude, longitude, _, ok := b.position()
+		if !ok {
+			return nil, false
+		}
+		t := b.dateTime()
+		return RMC{
+			BaseSentence: base,
+			Time:         signalKTime(t),
+			Validity:     NewString(ValidRMC),
+			Latitude:     NewFloat64(latitude),
+			Longitude:    NewFloat64(longitude),
+			Speed:        b.speed("navigation.speedOverGround", unit.Knot),
+			Course:       b.angle("navigation.courseOverGroundTrue"),
+			Date:         NewDate(t.Year()%100, int(t.Month()), t.Day()),
+			Variation:    b.signedAngle("navigation.magneticVariation"),
+		}, true
+	}},
+	{TypeGGA, []string{"navigation.position"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		latitude, longitude, altitude, ok := b.position()
+		if !ok {
+			return nil, false
+		}
+		fixQuality := NewString(GPS)
+		if methodQuality, ok := b.values["navigation.gnss.methodQuality"].(string); ok {
+			for quality, name := range signalKMethodQualities {
+				if name == methodQuality {
+					fixQuality = NewString(strconv.Itoa(int(quality)))
+				}
+			}
+		}
+		numSatellites := NewInvalidInt64("value is unavailable")
+		if satellites, ok := b.number("navigation.gnss.satellites"); ok {
+			numSatellites = NewInt64(int64(satellites))
+		}
+		return GGA{
+			BaseSentence:  base,
+			Time:          signalKTime(b.timestamp),
+			Latitude:      NewFloat64(latitude),
+			Longitude:     NewFloat64(longitude),
+			FixQuality:    fixQuality,
+			NumSatellites: numSatellites,
+			HDOP:          NewInvalidFloat64("value is unavailable"),
+			Altitude:      altitude,
+			Separation:    NewInvalidFloat64("value is unavailable"),
+			DGPSAge:       NewInvalidString("value is unavailable"),
+			DGPSId:        NewInvalidString("value is unavailable"),
+		}, true
+	}},
+	{TypeVTG, []string{"navigation.courseOverGroundTrue", "navigation.courseOverGroundMagnetic", "navigation.speedOverGround"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return VTG{
+			BaseSentence:     base,
+			TrueTrack:        b.angle("navigation.courseOverGroundTrue"),
+			MagneticTrack:    b.angle("navigation.courseOverGroundMagnetic"),
+			GroundSpeedKnots: Speed{Float64: b.speed("navigation.speedOverGround", unit.Knot), Unit: SpeedUnitKnots},
+			GroundSpeedKPH:   Speed{Float64: b.speed("navigation.speedOverGround", unit.KilometersPerHour), Unit: SpeedUnitKilometersPerHour},
+		}, true
+	}},
+	{TypeHDT, []string{"navigation.headingTrue"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return HDT{BaseSentence: base, Heading: b.angle("navigation.headingTrue"), True: true}, true
+	}},
+	{TypeVHW, []string{"navigation.speedThroughWater"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return VHW{
+			BaseSentence:           base,
+			TrueHeading:            b.angle("navigation.headingTrue"),
+			MagneticHeading:        b.angle("navigation.headingMagnetic"),
+			SpeedThroughWaterKnots: Speed{Float64: b.speed("navigation.speedThroughWater", unit.Knot), Unit: SpeedUnitKnots},
+			SpeedThroughWaterKPH:   Speed{Float64: b.speed("navigation.speedThroughWater", unit.KilometersPerHour), Unit: SpeedUnitKilometersPerHour},
+		}, true
+	}},
+	{TypeROT, []string{"navigation.rateOfTurn"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		rateOfTurn, ok := b.number("navigation.rateOfTurn")
+		if !ok {
+			return nil, false
+		}
+		degreesPerMinute := (unit.Angle(rateOfTurn) * unit.Radian).Degrees() * 60
+		return ROT{BaseSentence: base, RateOfTurn: NewFloat64(signalKRound(degreesPerMinute)), Status: NewString(ValidROT)}, true
+	}},
+	{TypeRSA, []string{"steering.rudderAngle"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return RSA{
+			BaseSentence:         base,
+			RudderAngleStarboard: b.signedAngle("steering.rudderAngle"),
+			StatusStarboard:      NewString(ValidRSA),
+			RudderAnglePortside:  NewInvalidFloat64("value is unavailable"),
+			StatusPortside:       NewString(InvalidRSA),
+		}, true
+	}},
+	{TypeMWV, []string{"environment.wind.angleApparent", "environment.wind.speedApparent"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return signalKMWV(b, base, "environment.wind.angleApparent", "environment.wind.speedApparent", ReferenceRelative)
+	}},
+	{TypeMWV, []string{"environment.wind.angleTrueWater", "environment.wind.speedTrue"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return signalKMWV(b, base, "environment.wind.angleTrueWater", "environment.wind.speedTrue", ReferenceTrue)
+	}},
+	{TypeMWD, []string{"environment.wind.directionTrue", "environment.wind.directionMagnetic", "environment.wind.speedTrue"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return MWD{
+			BaseSentence:               base,
+			WindDirectionTrue:          b.angle("environment.wind.directionTrue"),
+			WindDirectionMagnetic:      b.angle("environment.wind.directionMagnetic"),
+			WindSpeedInKnots:           Speed{Float64: b.speed("environment.wind.speedTrue", unit.Knot), Unit: SpeedUnitKnots},
+			WindSpeedInMetersPerSecond: Speed{Float64: b.speed("environment.wind.speedTrue", unit.MetersPerSecond), Unit: SpeedUnitMetersPerSecond},
+		}, true
+	}},
+	{TypeDPT, []string{"environment.depth.belowTransducer"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		depth, ok := b.number("environment.depth.belowTransducer")
+		if !ok {
+			return nil, false
+		}
+		offset := NewInvalidLength("value is unavailable")
+		if belowSurface, ok := b.number("environment.depth.belowSurface"); ok {
+			offset = NewLength(signalKRound(belowSurface-depth), LengthUnitMeters)
+		} else if belowKeel, ok := b.number("environment.depth.belowKeel"); ok {
+			offset = NewLength(signalKRound(belowKeel-depth), LengthUnitMeters)
+		}
+		return DPT{
+			BaseSentence: base,
+			Depth:        NewLength(signalKRound(depth), LengthUnitMeters),
+			Offset:       offset,
+			RangeScale:   NewInvalidLength("value is unavailable"),
+		}, true
+	}},
+	{TypeDBT, []string{"environment.depth.belowTransducer"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return DBT{
+			BaseSentence: base,
+			DepthFeet:    Length{Float64: b.length("environment.depth.belowTransducer", unit.Foot), Unit: LengthUnitFeet},
+			DepthMeters:  Length{Float64: b.length("environment.depth.belowTransducer", unit.Meter), Unit: LengthUnitMeters},
+			DepthFathoms: Length{Float64: b.length("environment.depth.belowTransducer", unit.Fathom), Unit: LengthUnitFathoms},
+		}, true
+	}},
+	{TypeMDA, []string{"environment.outside.pressure", "environment.outside.temperature", "environment.outside.relativeHumidity", "environment.outside.dewPointTemperature", "environment.water.temperature"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		humidity := NewInvalidFloat64("value is unavailable")
+		if v, ok := b.number("environment.outside.relativeHumidity"); ok {
+			humidity = NewFloat64(signalKRound(v * 100))
+		}
+		return MDA{
+			BaseSentence:                        base,
+			BarometricPressureInInchesOfMercury: Pressure{Float64: b.pressure("environment.outside.pressure", unit.InchOfMercury), Unit: PressureUnitInchesOfMercury},
+			BarometricPressureInBar:             Pressure{Float64: b.pressure("environment.outside.pressure", unit.Bar), Unit: PressureUnitBar},
+			AirTemperature:                      Temperature{Float64: b.celsius("environment.outside.temperature"), Unit: TemperatureUnitCelsius},
+			WaterTemperature:                    Temperature{Float64: b.celsius("environment.water.temperature"), Unit: TemperatureUnitCelsius},
+			RelativeHumidity:                    humidity,
+			DewPoint:                            Temperature{Float64: b.celsius("environment.outside.dewPointTemperature"), Unit: TemperatureUnitCelsius},
+			WindDirectionTrue:                   b.angle("environment.wind.directionTrue"),
+			WindDirectionMagnetic:               b.angle("environment.wind.directionMagnetic"),
+			WindSpeedInKnots:                    Speed{Float64: b.speed("environment.wind.speedTrue", unit.Knot), Unit: SpeedUnitKnots},
+			WindSpeedInMetersPerSecond:          Speed{Float64: b.speed("environment.wind.speedTrue", unit.MetersPerSecond), Unit: SpeedUnitMetersPerSecond},
+		}, true
+	}},
+	{TypeHEV, []string{"environment.heave"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		return HEV{BaseSentence: base, Heave: b.length("environment.heave", unit.Meter)}, true
+	}},
+	{TypeZDA, []string{"navigation.datetime"}, func(b *SignalKBridge, base BaseSentence) (Sentence, bool) {
+		t := b.dateTime()
+		return ZDA{
+			BaseSentence:  base,
+			Time:          signalKTime(t),
+			Day:           NewInt64(int64(t.Day())),
+			Month:         NewInt64(int64(t.Month())),
+			Year:          NewInt64(int64(t.Year())),
+			OffsetHours:   NewInt64(0),
+			OffsetMinutes: NewInt64(0),
+		}, true
+	}},
+}
+
+// signalKMWV creates a MWV sentence with the wind speed in knots
+func signalKMWV(b *SignalKBridge, base BaseSentence, anglePath, speedPath, reference string) (Sentence, bool) {
+	angle := b.angle(anglePath)
+	speed := b.speed(speedPath, unit.Knot)
+	if !angle.Valid && !speed.Valid {
+		return nil, false
+	}
+	return MWV{
+		BaseSentence: base,
+		Angle:        angle,
+		Reference:    NewString(reference),
+		WindSpeed:    Speed{Float64: speed, Unit: SpeedUnitKnots},
+		Status:       NewString(ValidMWV),
+	}, true
+}
+
+// NewSignalKBridge constructor
+func NewSignalKBridge(config SignalKBridgeConfig) *SignalKBridge {
+	if config.Talker == "" {
+		config.Talker = DefaultSignalKBridgeTalker
+	}
+	return &SignalKBridge{
+		config: config,
+		values: map[string]interface{}{},
+		sent:   map[int]time.Time{},
+	}
+}
+
+// Add converts the JSON of a Signal K delta message received now, see AddAt
+func (b *SignalKBridge) Add(data []byte) ([]Sentence, error) {
+	return b.AddAt(data, time.Now())
+}
+
+// AddAt converts the JSON of a Signal K delta message received at the given time, see AddDeltaAt
+func (b *SignalKBridge) AddAt(data []byte, at time.Time) ([]Sentence, error) {
+	var delta SignalKDelta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return nil, err
+	}
+	return b.AddDeltaAt(delta, at), nil
+}
+
+// AddDeltaAt converts a Signal K delta message received at the given time to the sentences whose
+// values are updated by the delta, the raw text of the sentences is set. The sentences that are
+// created within the interval of the previous sentence of the same kind are left out, the MWV
+// sentences with the apparent and the true wind are different kinds.
+func (b *SignalKBridge) AddDeltaAt(delta SignalKDelta, at time.Time) []Sentence {
+	if delta.Context != "" && delta.Context != SignalKSelf && delta.Context != b.config.Self {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	updated := map[string]bool{}
+	for _, update := range delta.Updates {
+		b.timestamp = update.Timestamp
+		if b.timestamp.IsZero() {
+			b.timestamp = at
+		}
+		for _, v := range update.Values {
+			b.values[v.Path] = v.Value
+			updated[v.Path] = true
+		}
+	}
+
+	result := make([]Sentence, 0)
+	for i, s := range signalKSentences {
+		if !signalKTriggered(s.triggers, updated) {
+			continue
+		}
+		if sent, ok := b.sent[i]; ok && at.Sub(sent) < b.config.Interval {
+			continue
+		}
+		sentence, ok := s.create(b, BaseSentence{Talker: b.config.Talker, Type: s.typ})
+		if !ok {
+			continue
+		}
+		result = append(result, withRaw(sentence))
+		b.sent[i] = at
+	}
+	return result
+}
+
+// signalKTriggered returns true when one of the triggers is updated
+func signalKTriggered(triggers []string, updated map[string]bool) bool {
+	for _, trigger := range triggers {
+		if updated[trigger] {
+			return true
+		}
+	}
+	return false
+}
+
+// number returns the latest value of the path as a float64
+func (b *SignalKBridge) number(path string) (float64, bool) {
+	switch v := b.values[path].(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// position returns the latest position, the altitude is invalid when the position has none
+func (b *SignalKBridge) position() (float64, float64, Float64, bool) {
+	switch v := b.values["navigation.position"].(type) {
+	case SignalKPosition:
+		altitude := NewInvalidFloat64("value is unavailable")
+		if v.Altitude != nil {
+			altitude = NewFloat64(signalKRound(*v.Altitude))
+		}
+		return v.Latitude, v.Longitude, altitude, true
+	case map[string]interface{}:
+		latitude, ok := v["latitude"].(float64)
+		if !ok {
+			return 0, 0, Float64{}, false
+		}
+		longitude, ok := v["longitude"].(float64)
+		if !ok {
+			return 0, 0, Float64{}, false
+		}
+		altitude := NewInvalidFloat64("value is unavailable")
+		if a, ok := v["altitude"].(float64); ok {
+			altitude = NewFloat64(signalKRound(a))
+		}
+		return latitude, longitude, altitude, true
+	}
+	return 0, 0, Float64{}, false
+}
+
+// dateTime returns the latest navigation.datetime or the timestamp of the latest update
+func (b *SignalKBridge) dateTime() time.Time {
+	if v, ok := b.values["navigation.datetime"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.UTC()
+		}
+	}
+	return b.timestamp.UTC()
+}
+
+// angle returns the latest angle of the path in degrees in the range 0 to 360
+func (b *SignalKBridge) angle(path string) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	degrees := math.Mod((unit.Angle(v) * unit.Radian).Degrees(), 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return NewFloat64(signalKRound(degrees))
+}
+
+// signedAngle returns the latest angle of the path in degrees, e.g. -3.1 for 3.1 degrees to port
+func (b *SignalKBridge) signedAngle(path string) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	return NewFloat64(signalKRound((unit.Angle(v) * unit.Radian).Degrees()))
+}
+
+// speed returns the latest speed of the path in the given unit
+func (b *SignalKBridge) speed(path string, u unit.Speed) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	return NewFloat64(signalKRound(float64(unit.Speed(v) * unit.MetersPerSecond / u)))
+}
+
+// length returns the latest length of the path in the given unit
+func (b *SignalKBridge) length(path string, u unit.Length) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	return NewFloat64(signalKRound(float64(unit.Length(v) * unit.Meter / u)))
+}
+
+// pressure returns the latest pressure of the path in the given unit
+func (b *SignalKBridge) pressure(path string, u unit.Pressure) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	return NewFloat64(signalKRound(float64(unit.Pressure(v) * unit.Pascal / u)))
+}
+
+// celsius returns the latest temperature of the path in degrees Celsius
+func (b *SignalKBridge) celsius(path string) Float64 {
+	v, ok := b.number(path)
+	if !ok {
+		return NewInvalidFloat64("value is unavailable")
+	}
+	return NewFloat64(signalKRound(unit.FromKelvin(v).Celsius()))
+}
+
+// signalKTime returns the time of day of the time
+func signalKTime(t time.Time) Time {
+	return NewTime(t.Hour(), t.Minute(), t.Second(), t.Nanosecond()/int(time.Millisecond))
+}
+
+// signalKRound rounds the converted value to remove the noise of the unit conversion
+func signalKRound(v float64) float64 {
+	p := math.Pow10(signalKBridgeDecimals)
+	return math.Round(v*p) / p
+}
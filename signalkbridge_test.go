@@ -0,0 +1,162 @@
+package nmea_test
+
+import (
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// encodeAll encodes the sentences
+func encodeAll(sentences []Sentence) []string {
+	result := make([]string, 0, len(sentences))
+	for _, s := range sentences {
+		encoded, err := Encode(s)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		result = append(result, encoded)
+	}
+	return result
+}
+
+var _ = Describe("SignalKBridge", func() {
+	var (
+		bridge *SignalKBridge
+		start  time.Time
+	)
+	BeforeEach(func() {
+		start = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		bridge = NewSignalKBridge(SignalKBridgeConfig{})
+	})
+
+	DescribeTable("Round tripping a sentence through a Signal K delta",
+		func(raw string, talker string) {
+			parsed, err := Parse(raw)
+			Expect(err).ToNot(HaveOccurred())
+			delta, err := ToSignalKDelta(parsed)
+			Expect(err).ToNot(HaveOccurred())
+			bridge = NewSignalKBridge(SignalKBridgeConfig{Talker: talker})
+			Expect(encodeAll(bridge.AddDeltaAt(delta, start))).To(ContainElement(raw))
+		},
+		Entry("HDT", "$GPHDT,123.456,T*32", "GP"),
+		Entry("RSA", "$RIRSA,3.1,A,,V*60", "RI"),
+		Entry("MWV", "$WIMWV,117.5,R,4.6,N,A*23", "WI"),
+		Entry("MWV with the true wind", "$WIMWV,117.5,T,4.6,N,A*25", "WI"),
+		Entry("DPT", "$SDDPT,0.5,0.5,*7B", "SD"),
+		Entry("ROT", "$GPROT,3.1,A*33", "GP"),
+		Entry("HEV", "$GPHEV,-0.07*54", "GP"),
+	)
+
+	Context("when a delta with a position is added", func() {
+		var sentences []Sentence
+		BeforeEach(func() {
+			var err error
+			sentences, err = bridge.AddAt([]byte(`{
+				"context": "vessels.self",
+				"updates": [{
+					"source": {"label": "gps", "type": "NMEA2000"},
+					"timestamp": "2021-04-23T11:45:09.3Z",
+					"values": [
+						{"path": "navigation.position", "value": {"latitude": 51.700214, "longitude": 4.8668661, "altitude": 12.5}},
+						{"path": "navigation.speedOverGround", "value": 5.144444},
+						{"path": "navigation.courseOverGroundTrue", "value": 6.0318578},
+						{"path": "navigation.gnss.methodQuality", "value": "DGNSS fix"},
+						{"path": "navigation.gnss.satellites", "value": 9}
+					]
+				}]
+			}`), start)
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("creates a RMC, GGA and VTG sentence with the configured talker", func() {
+			Expect(sentences).To(HaveLen(3))
+			Expect(sentences[0].Prefix()).To(Equal("IIRMC"))
+			Expect(sentences[1].Prefix()).To(Equal("IIGGA"))
+			Expect(sentences[2].Prefix()).To(Equal("IIVTG"))
+		})
+		It("converts the values to the units of the sentences", func() {
+			rmc := sentences[0].(RMC)
+			Expect(rmc.Time).To(Equal(NewTime(11, 45, 9, 300)))
+			Expect(rmc.Date).To(Equal(NewDate(21, 4, 23)))
+			Expect(rmc.Speed).To(Equal(NewFloat64(10.0)))
+			Expect(rmc.Course).To(Equal(NewFloat64(345.6)))
+			gga := sentences[1].(GGA)
+			Expect(gga.FixQuality).To(Equal(NewString(DGPS)))
+			Expect(gga.NumSatellites).To(Equal(NewInt64(9)))
+			Expect(gga.Altitude).To(Equal(NewFloat64(12.5)))
+		})
+		It("sets the raw text of the sentences", func() {
+			Expect(sentences[2].String()).To(Equal("$IIVTG,345.6,T,,M,10,N,18.52,K*52"))
+		})
+		It("combines the values with the values of later deltas", func() {
+			delta := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "navigation.speedOverGround", Value: 0.0}},
+			}}}
+			sentences := bridge.AddDeltaAt(delta, start.Add(time.Second))
+			Expect(encodeAll(sentences)).To(Equal([]string{"$IIVTG,345.6,T,,M,0,N,0,K*73"}))
+		})
+	})
+	Context("when a delta with only the true wind direction is added", func() {
+		It("does not create a MWV sentence with the true wind", func() {
+			delta := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "environment.wind.directionTrue", Value: 4.712389}},
+			}}}
+			sentences := bridge.AddDeltaAt(delta, start)
+			Expect(sentences).ToNot(ContainElement(BeAssignableToTypeOf(MWV{})))
+			Expect(encodeAll(sentences)).To(ContainElement(HavePrefix("$IIMWD,270,T,")))
+		})
+	})
+	Context("when a delta with a date and time is added", func() {
+		It("creates a ZDA sentence in UTC", func() {
+			delta := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "navigation.datetime", Value: "1996-07-12T19:28:09.456+02:00"}},
+			}}}
+			Expect(encodeAll(bridge.AddDeltaAt(delta, start))).To(Equal([]string{"$IIZDA,172809.456,12,07,1996,00,00*40"}))
+		})
+	})
+	Context("when the interval has not passed", func() {
+		It("leaves out the sentences of the same type", func() {
+			bridge = NewSignalKBridge(SignalKBridgeConfig{Interval: time.Second})
+			delta := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "navigation.headingTrue", Value: 1.0}},
+			}}}
+			Expect(bridge.AddDeltaAt(delta, start)).To(HaveLen(1))
+			Expect(bridge.AddDeltaAt(delta, start.Add(500*time.Millisecond))).To(BeEmpty())
+			Expect(bridge.AddDeltaAt(delta, start.Add(time.Second))).To(HaveLen(1))
+		})
+		It("does not leave out the true wind after the apparent wind", func() {
+			bridge = NewSignalKBridge(SignalKBridgeConfig{Interval: time.Second})
+			apparent := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "environment.wind.angleApparent", Value: 0.5}},
+			}}}
+			trueWind := SignalKDelta{Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "environment.wind.speedTrue", Value: 5.0}},
+			}}}
+			Expect(bridge.AddDeltaAt(apparent, start)).To(HaveLen(1))
+			sentences := bridge.AddDeltaAt(trueWind, start.Add(100*time.Millisecond))
+			Expect(sentences).To(ContainElement(BeAssignableToTypeOf(MWV{})))
+			Expect(sentences[0].(MWV).Reference.Value).To(Equal(ReferenceTrue))
+			Expect(bridge.AddDeltaAt(apparent, start.Add(500*time.Millisecond))).To(BeEmpty())
+		})
+	})
+	Context("when a delta of another vessel is added", func() {
+		It("is ignored", func() {
+			delta := SignalKDelta{Context: "vessels.urn:mrn:imo:mmsi:232008128", Updates: []SignalKUpdate{{
+				Timestamp: start,
+				Values:    []SignalKValue{{Path: "navigation.headingTrue", Value: 1.0}},
+			}}}
+			Expect(bridge.AddDeltaAt(delta, start)).To(BeEmpty())
+		})
+	})
+	Context("when the delta is not valid JSON", func() {
+		It("returns an error", func() {
+			_, err := bridge.Add([]byte(`{"updates":`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,500 @@
+package nmea
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// SignalKSelf is the context of the values of the own vessel
+	SignalKSelf = "vessels.self"
+	// SignalKSourceType is the type of the source of the values that are converted from NMEA 0183
+	SignalKSourceType = "NMEA0183"
+	// signalKDefaultLabel is the label of the source when the tag block has no source
+	signalKDefaultLabel = "nmea0183"
+)
+
+// ErrNoSignalKValues is returned when a sentence has no values that can be converted to Signal K
+var ErrNoSignalKValues = errors.New("nmea: sentence has no Signal K values")
+
+// SignalKDelta is a Signal K delta message, see https://signalk.org/specification/latest/doc/data_model.html
+type SignalKDelta struct {
+	Context string          `json:"context"`
+	Updates []SignalKUpdate `json:"updates"`
+}
+
+// SignalKUpdate is an update of a Signal K delta message with the values of a single source
+type SignalKUpdate struct {
+	Source    SignalKSource  `json:"source"`
+	Timestamp time.Time      `json:"timestamp"`
+	Values    []SignalKValue `json:"values"`
+}
+
+// SignalKSource is the source of the values of an update
+type SignalKSource struct {
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	Talker   string `json:"talker,omitempty"`
+	Sentence string `json:"sentence"`
+}
+
+// SignalKValue is the value of a Signal K path, the value is in SI units
+type SignalKValue struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// SignalKPosition is the value of the navigation.position path
+type SignalKPosition struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+}
+
+// SignalKShipType is the value of the design.aisShipType path
+type SignalKShipType struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// SignalKConfig contains the options of the conversion to Signal K, the zero value converts like
+// ToSignalKDelta
+type SignalKConfig struct {
+	// Label is the label of the source when the tag block has no source, nmea0183 when it is empty
+	Label string
+	// Now returns the timestamp of sentences without a date and time, time.Now when it is nil
+	Now func() time.Time
+}
+
+// magneticCourseOverGround retrieves the magnetic course over ground, MagneticCourseOverGround has
+// a misspelled method name that is kept for compatibility
+type magneticCourseOverGround interface {
+	GetMagneticCourseOverGround() (float64, error)
+}
+
+// gnssFixQuality retrieves the typed fix quality from the sentence
+type gnssFixQuality interface {
+	GetGNSSFixQuality() (GNSSFixQuality, error)
+}
+
+// aisShipType retrieves the typed ship type from the sentence
+type aisShipType interface {
+	GetAISShipType() (AISShipType, error)
+}
+
+// signalKMethodQualities are the values of the navigation.gnss.methodQuality path by fix quality
+var signalKMethodQualities = map[GNSSFixQuality]string{
+	FixQualityInvalid:    "no GPS",
+	FixQualityGPS:        "GNSS Fix",
+	FixQualityDGPS:       "DGNSS fix",
+	FixQualityPPS:        "Precise GNSS",
+	FixQualityRTK:        "RTK fixed integer",
+	FixQualityFloatRTK:   "RTK float",
+	FixQualityEstimated:  "Estimated (DR) mode",
+	FixQualityManual:     "Manual input",
+	FixQualitySimulation: "Simulator mode",
+}
+
+// signalKPath converts a value of a sentence to the value of a Signal K path, ok is false when the
+// sentence does not have the value
+type signalKPath struct {
+	path  string
+	value func(s Sentence) (v interface{}, ok bool)
+}
+
+// signalKFloat64 returns the value of a getter that returns a float64
+func signalKFloat64(v float64, err error) (interface{}, bool) {
+	return v, err == nil
+}
+
+// signalKString returns the value of a getter that returns a non empty string
+func signalKString(v string, err error) (interface{}, bool) {
+	return v, err == nil && v != ""
+}
+
+// signalKPaths are the Signal K paths in the order they are added to an update
+var signalKPaths = []signalKPath{
+	{"", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(MMSI); ok {
+			if mmsi, err := v.GetMMSI(); err == nil {
+				return map[string]string{"mmsi": mmsi}, true
+			}
+		}
+		return nil, false
+	}},
+	{"", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(VesselName); ok {
+			if name, err := v.GetVesselName(); err == nil && name != "" {
+				return map[string]string{"name": name}, true
+			}
+		}
+		return nil, false
+	}},
+	{"navigation.position", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(Position3D); ok {
+			if latitude, longitude, altitude, err := v.GetPosition3D(); err == nil {
+				return SignalKPosition{Latitude: latitude, Longitude: longitude, Altitude: &altitude}, true
+			}
+		}
+		if v, ok := s.(Position2D); ok {
+			if latitude, longitude, err := v.GetPosition2D(); err == nil {
+				return SignalKPosition{Latitude: latitude, Longitude: longitude}, true
+			}
+		}
+		return nil, false
+	}},
+	{"navigation.datetime", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DateTime); ok {
+			return signalKString(v.GetDateTime())
+		}
+		return nil, false
+	}},
+	{"navigation.headingTrue", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(TrueHeading); ok {
+			return signalKFloat64(v.GetTrueHeading())
+		}
+		return nil, false
+	}},
+	{"navigation.headingMagnetic", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(MagneticHeading); ok {
+			return signalKFloat64(v.GetMagneticHeading())
+		}
+		return nil, false
+	}},
+	{"navigation.magneticVariation", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(MagneticVariation); ok {
+			return signalKFloat64(v.GetMagneticVariation())
+		}
+		return nil, false
+	}},
+	{"navigation.rateOfTurn", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(RateOfTurn); ok {
+			return signalKFloat64(v.GetRateOfTurn())
+		}
+		return nil, false
+	}},
+	{"navigation.courseOverGroundTrue", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(TrueCourseOverGround); ok {
+			return signalKFloat64(v.GetTrueCourseOverGround())
+		}
+		return nil, false
+	}},
+	{"navigation.courseOverGroundMagnetic", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(magneticCourseOverGround); ok {
+			return signalKFloat64(v.GetMagneticCourseOverGround())
+		}
+		return nil, false
+	}},
+	{"navigation.speedOverGround", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(SpeedOverGround); ok {
+			return signalKFloat64(v.GetSpeedOverGround())
+		}
+		return nil, false
+	}},
+	{"navigation.speedThroughWater", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(SpeedThroughWater); ok {
+			return signalKFloat64(v.GetSpeedThroughWater())
+		}
+		return nil, false
+	}},
+	{"navigation.state", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(NavigationStatus); ok {
+			return signalKString(v.GetNavigationStatus())
+		}
+		return nil, false
+	}},
+	{"navigation.destination.commonName", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(Destination); ok {
+			return signalKString(v.GetDestination())
+		}
+		return nil, false
+	}},
+	{"navigation.destination.eta", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(ETA); ok {
+			if eta, err := v.GetETA(); err == nil {
+				return eta.UTC().Format(time.RFC3339Nano), true
+			}
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.satellites", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(NumberOfSatellites); ok {
+			if satellites, err := v.GetNumberOfSatellites(); err == nil {
+				return satellites, true
+			}
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.methodQuality", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(gnssFixQuality); ok {
+			if quality, err := v.GetGNSSFixQuality(); err == nil {
+				methodQuality, ok := signalKMethodQualities[quality]
+				return methodQuality, ok
+			}
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.horizontalDilution", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(HorizontalDilution); ok {
+			return signalKFloat64(v.GetHorizontalDilution())
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.positionDilution", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(PositionDilution); ok {
+			return signalKFloat64(v.GetPositionDilution())
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.antennaAltitude", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(AntennaAltitude); ok {
+			return signalKFloat64(v.GetAntennaAltitude())
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.geoidalSeparation", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(GeoidalSeparation); ok {
+			return signalKFloat64(v.GetGeoidalSeparation())
+		}
+		return nil, false
+	}},
+	{"navigation.gnss.differentialAge", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DifferentialAge); ok {
+			return signalKFloat64(v.GetDifferentialAge())
+		}
+		return nil, false
+	}},
+	{"environment.depth.belowSurface", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DepthBelowSurface); ok {
+			return signalKFloat64(v.GetDepthBelowSurface())
+		}
+		return nil, false
+	}},
+	{"environment.depth.belowKeel", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DepthBelowKeel); ok {
+			return signalKFloat64(v.GetDepthBelowKeel())
+		}
+		return nil, false
+	}},
+	{"environment.depth.belowTransducer", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DepthBelowTransducer); ok {
+			return signalKFloat64(v.GetDepthBelowTransducer())
+		}
+		return nil, false
+	}},
+	{"environment.heave", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(Heave); ok {
+			return signalKFloat64(v.GetHeave())
+		}
+		return nil, false
+	}},
+	{"environment.water.temperature", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(WaterTemperature); ok {
+			return signalKFloat64(v.GetWaterTemperature())
+		}
+		return nil, false
+	}},
+	{"environment.outside.temperature", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(OutsideTemperature); ok {
+			return signalKFloat64(v.GetOutsideTemperature())
+		}
+		return nil, false
+	}},
+	{"environment.outside.dewPointTemperature", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(DewPointTemperature); ok {
+			return signalKFloat64(v.GetDewPointTemperature())
+		}
+		return nil, false
+	}},
+	{"environment.outside.relativeHumidity", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(Humidity); ok {
+			return signalKFloat64(v.GetHumidity())
+		}
+		return nil, false
+	}},
+	{"environment.outside.pressure", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(OutsidePressure); ok {
+			return signalKFloat64(v.GetOutsidePressure())
+		}
+		return nil, false
+	}},
+	{"environment.wind.angleApparent", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(RelativeWindDirection); ok {
+			return signalKFloat64(v.GetRelativeWindDirection())
+		}
+		return nil, false
+	}},
+	{"environment.wind.angleTrueWater", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(TrueWindAngle); ok {
+			return signalKFloat64(v.GetTrueWindAngle())
+		}
+		return nil, false
+	}},
+	{"environment.wind.directionTrue", func(s Sentence) (interface{}, bool) {
+		// the true wind of a sentence with a true wind angle is relative to the bow, not to north
+		if _, ok := s.(TrueWindAngle); ok {
+			return nil, false
+		}
+		if v, ok := s.(TrueWindDirection); ok {
+			return signalKFloat64(v.GetTrueWindDirection())
+		}
+		return nil, false
+	}},
+	{"environment.wind.directionMagnetic", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(MagneticWindDirection); ok {
+			return signalKFloat64(v.GetMagneticWindDirection())
+		}
+		return nil, false
+	}},
+	{"environment.wind.speedApparent", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(WindSpeed); ok && windReference(s) == ReferenceRelative {
+			return signalKFloat64(v.GetWindSpeed())
+		}
+		return nil, false
+	}},
+	{"environment.wind.speedTrue", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(WindSpeed); ok && windReference(s) == ReferenceTrue {
+			return signalKFloat64(v.GetWindSpeed())
+		}
+		return nil, false
+	}},
+	{"steering.rudderAngle", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(RudderAngle); ok {
+			if angle, err := v.GetRudderAngle(); err == nil {
+				return angle, true
+			}
+			return signalKFloat64(v.GetRudderAngleStarboard())
+		}
+		return nil, false
+	}},
+	{"communication.callsignVhf", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(CallSign); ok {
+			return signalKString(v.GetCallSign())
+		}
+		return nil, false
+	}},
+	{"registrations.imo", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(IMONumber); ok {
+			if imo, err := v.GetIMONumber(); err == nil && imo != "" && imo != "0" {
+				return "IMO " + imo, true
+			}
+		}
+		return nil, false
+	}},
+	{"design.aisShipType", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(VesselType); ok {
+			if t, ok := s.(aisShipType); ok {
+				name, err := v.GetVesselType()
+				if id, err2 := t.GetAISShipType(); err == nil && err2 == nil {
+					return SignalKShipType{ID: int(id), Name: name}, true
+				}
+			}
+		}
+		return nil, false
+	}},
+	{"design.length", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(VesselLength); ok {
+			if length, err := v.GetVesselLength(); err == nil {
+				return map[string]float64{"overall": length}, true
+			}
+		}
+		return nil, false
+	}},
+	{"design.beam", func(s Sentence) (interface{}, bool) {
+		if v, ok := s.(VesselBeam); ok {
+			return signalKFloat64(v.GetVesselBeam())
+		}
+		return nil, false
+	}},
+}
+
+// windReference returns ReferenceRelative when the wind of the sentence is relative to the vessel
+// and ReferenceTrue when it is true, the reference is empty when a MWV sentence has no valid reference
+func windReference(s Sentence) string {
+	switch v := s.(type) {
+	case MWV:
+		if v.Reference.Valid {
+			return v.Reference.Value
+		}
+		return ""
+	case VWR:
+		return ReferenceRelative
+	}
+	return ReferenceTrue
+}
+
+// ToSignalKDelta converts the values of the sentence to a Signal K delta message, see
+// ToSignalKDeltaWithConfig
+func ToSignalKDelta(s Sentence) (SignalKDelta, error) {
+	return ToSignalKDeltaWithConfig(s, SignalKConfig{})
+}
+
+// ToSignalKDeltaWithConfig converts the values of the sentence to a Signal K delta message with a
+// single update. The context is the MMSI of AIS messages and the own vessel for other sentences.
+// The label of the source is the source of the tag block and the timestamp is the time of the tag
+// block or the date and time of the sentence, the config is used when the sentence has neither.
+// ErrNoSignalKValues is returned when the sentence has no values.
+func ToSignalKDeltaWithConfig(s Sentence, config SignalKConfig) (SignalKDelta, error) {
+	values := make([]SignalKValue, 0)
+	for _, p := range signalKPaths {
+		if v, ok := p.value(s); ok {
+			values = append(values, SignalKValue{Path: p.path, Value: v})
+		}
+	}
+	if len(values) == 0 {
+		return SignalKDelta{}, fmt.Errorf("%w [%s]", ErrNoSignalKValues, s.Prefix())
+	}
+	return SignalKDelta{
+		Context: signalKContext(s),
+		Updates: []SignalKUpdate{{
+			Source:    signalKSource(s, config),
+			Timestamp: signalKTimestamp(s, config),
+			Values:    values,
+		}},
+	}, nil
+}
+
+// signalKContext returns the context of the vessel of an AIS message or the own vessel
+func signalKContext(s Sentence) string {
+	if v, ok := s.(MMSI); ok {
+		if mmsi, err := v.GetMMSI(); err == nil {
+			return "vessels.urn:mrn:imo:mmsi:" + mmsi
+		}
+	}
+	return SignalKSelf
+}
+
+// signalKSource returns the source of the sentence, the label is the source of the tag block
+func signalKSource(s Sentence, config SignalKConfig) SignalKSource {
+	source := SignalKSource{
+		Label:    config.Label,
+		Type:     SignalKSourceType,
+		Talker:   s.TalkerID(),
+		Sentence: s.DataType(),
+	}
+	if t, ok := s.(tagBlocker); ok && t.tagBlock().Valid && t.tagBlock().Source.Valid {
+		source.Label = t.tagBlock().Source.Value
+	}
+	if source.Label == "" {
+		source.Label = signalKDefaultLabel
+	}
+	return source
+}
+
+// signalKTimestamp returns the time of the tag block, the date and time of the sentence or the
+// current time in this order
+func signalKTimestamp(s Sentence, config SignalKConfig) time.Time {
+	if t, ok := s.(tagBlocker); ok && t.tagBlock().Valid && t.tagBlock().Time.Valid {
+		return t.tagBlock().TimeValue()
+	}
+	if v, ok := s.(Timestamp); ok {
+		if t, err := v.GetTimestamp(); err == nil {
+			return t
+		}
+	}
+	if config.Now != nil {
+		return config.Now().UTC()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,198 @@
+package nmea_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// signalKValues returns the values of the single update of the delta keyed on the path
+func signalKValues(delta SignalKDelta) map[string]interface{} {
+	ExpectWithOffset(1, delta.Updates).To(HaveLen(1))
+	result := map[string]interface{}{}
+	for _, v := range delta.Updates[0].Values {
+		result[v.Path] = v.Value
+	}
+	return result
+}
+
+var _ = Describe("SignalKDelta", func() {
+	var (
+		delta SignalKDelta
+		err   error
+	)
+	toDelta := func(raw string, config SignalKConfig) {
+		parsed, parseErr := Parse(raw)
+		Expect(parseErr).ToNot(HaveOccurred())
+		delta, err = ToSignalKDeltaWithConfig(parsed, config)
+	}
+
+	Context("when a RMC sentence is converted", func() {
+		BeforeEach(func() {
+			toDelta("$GPRMC,114509.30,A,5142.01288,N,00452.01197,E,0.0,345.6,230421,0.3,E,A,C*5C", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("uses the own vessel as context", func() {
+			Expect(delta.Context).To(Equal(SignalKSelf))
+		})
+		It("uses the date and time of the sentence as timestamp", func() {
+			Expect(delta.Updates[0].Timestamp).To(Equal(time.Date(2021, 4, 23, 11, 45, 9, 300000000, time.UTC)))
+		})
+		It("derives the source from the talker", func() {
+			Expect(delta.Updates[0].Source).To(Equal(SignalKSource{Label: "nmea0183", Type: "NMEA0183", Talker: "GP", Sentence: "RMC"}))
+		})
+		It("contains the values in SI units", func() {
+			values := signalKValues(delta)
+			Expect(values).To(HaveKey("navigation.position"))
+			position := values["navigation.position"].(SignalKPosition)
+			Expect(position.Latitude).To(BeNumerically("~", 51.700215, 0.000001))
+			Expect(position.Longitude).To(BeNumerically("~", 4.866866, 0.000001))
+			Expect(position.Altitude).To(BeNil())
+			Expect(values).To(HaveKeyWithValue("navigation.speedOverGround", 0.0))
+			Expect(values).To(HaveKeyWithValue("navigation.courseOverGroundTrue", BeNumerically("~", 6.0318, 0.0001)))
+			Expect(values).To(HaveKeyWithValue("navigation.magneticVariation", BeNumerically("~", 0.005236, 0.000001)))
+			Expect(values).To(HaveKeyWithValue("navigation.datetime", "2021-04-23T11:45:09.3Z"))
+		})
+	})
+	Context("when a ZDA sentence is converted", func() {
+		It("uses the date and time of the sentence as timestamp", func() {
+			toDelta("$GPZDA,172809.456,12,07,1996,00,00*57", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(delta.Updates[0].Timestamp).To(Equal(time.Date(1996, 7, 12, 17, 28, 9, 456000000, time.UTC)))
+			Expect(signalKValues(delta)).To(HaveKeyWithValue("navigation.datetime", "1996-07-12T17:28:09.456Z"))
+		})
+	})
+	Context("when a GGA sentence is converted", func() {
+		It("contains the altitude and the quality of the fix", func() {
+			toDelta("$GNGGA,203415.000,6325.6138,N,01021.4290,E,1,8,2.42,72.5,M,41.5,M,,*7C", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(*values["navigation.position"].(SignalKPosition).Altitude).To(Equal(72.5))
+			Expect(values).To(HaveKeyWithValue("navigation.gnss.satellites", int64(8)))
+			Expect(values).To(HaveKeyWithValue("navigation.gnss.methodQuality", "GNSS Fix"))
+			Expect(values).To(HaveKeyWithValue("navigation.gnss.horizontalDilution", 2.42))
+			Expect(values).To(HaveKeyWithValue("navigation.gnss.antennaAltitude", 72.5))
+			Expect(values).To(HaveKeyWithValue("navigation.gnss.geoidalSeparation", 41.5))
+			Expect(values).ToNot(HaveKey("navigation.gnss.differentialAge"))
+		})
+	})
+	Context("when a MWV sentence with a relative wind is converted", func() {
+		It("contains the apparent wind", func() {
+			toDelta("$WIMWV,117.5,R,4.6,N,A*23", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("environment.wind.angleApparent", BeNumerically("~", 2.0508, 0.0001)))
+			Expect(values).To(HaveKeyWithValue("environment.wind.speedApparent", BeNumerically("~", 2.3664, 0.0001)))
+			Expect(values).ToNot(HaveKey("environment.wind.speedTrue"))
+		})
+	})
+	Context("when a MWV sentence with a relative wind without an angle is converted", func() {
+		It("contains the apparent wind speed", func() {
+			toDelta("$WIMWV,,R,4.6,N,A*0F", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("environment.wind.speedApparent", BeNumerically("~", 2.3664, 0.0001)))
+			Expect(values).ToNot(HaveKey("environment.wind.speedTrue"))
+		})
+	})
+	Context("when a MWV sentence without a reference is converted", func() {
+		It("does not contain the wind speed", func() {
+			toDelta("$WIMWV,117.5,,4.6,N,A*71", SignalKConfig{})
+			Expect(err).To(MatchError(ErrNoSignalKValues))
+		})
+	})
+	Context("when a VWR sentence without an angle is converted", func() {
+		It("contains the apparent wind speed", func() {
+			toDelta("$IIVWR,,,9.0,N,4.6,M,16.7,K*0E", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("environment.wind.speedApparent", 4.6))
+			Expect(values).ToNot(HaveKey("environment.wind.speedTrue"))
+		})
+	})
+	Context("when a MWV sentence with a true wind is converted", func() {
+		It("contains the true wind", func() {
+			toDelta("$WIMWV,117.5,T,4.6,M,A*26", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("environment.wind.angleTrueWater", BeNumerically("~", 2.0508, 0.0001)))
+			Expect(values).ToNot(HaveKey("environment.wind.directionTrue"))
+			Expect(values).To(HaveKeyWithValue("environment.wind.speedTrue", 4.6))
+			Expect(values).ToNot(HaveKey("environment.wind.speedApparent"))
+		})
+	})
+	Context("when a MWD sentence is converted", func() {
+		It("contains the true wind direction relative to north", func() {
+			toDelta("$WIMWD,351.1,T,350.8,M,8.4,N,4.3,M*59", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("environment.wind.directionTrue", BeNumerically("~", 6.1279, 0.0001)))
+			Expect(values).ToNot(HaveKey("environment.wind.angleTrueWater"))
+		})
+	})
+	Context("when a RSA sentence is converted", func() {
+		It("contains the rudder angle", func() {
+			toDelta("$RIRSA,3.1,A,,V*60", SignalKConfig{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signalKValues(delta)).To(HaveKeyWithValue("steering.rudderAngle", BeNumerically("~", 0.0541, 0.0001)))
+		})
+	})
+	Context("when a HDT sentence without a tag block is converted", func() {
+		It("uses the label and the time of the config", func() {
+			now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+			toDelta("$GPHDT,123.456,T*32", SignalKConfig{Label: "gyro", Now: func() time.Time { return now }})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(delta.Updates[0].Source.Label).To(Equal("gyro"))
+			Expect(delta.Updates[0].Timestamp).To(Equal(now))
+			Expect(signalKValues(delta)).To(HaveKeyWithValue("navigation.headingTrue", BeNumerically("~", 2.1547, 0.0001)))
+		})
+	})
+	Context("when an AIS message with a tag block is converted", func() {
+		BeforeEach(func() {
+			toDelta("\\s:Satellite_1,c:1553390539*0E\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52", SignalKConfig{Label: "ais"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("uses the MMSI as context", func() {
+			Expect(delta.Context).To(Equal("vessels.urn:mrn:imo:mmsi:232008128"))
+		})
+		It("derives the source and the timestamp from the tag block", func() {
+			Expect(delta.Updates[0].Source).To(Equal(SignalKSource{Label: "Satellite_1", Type: "NMEA0183", Talker: "AI", Sentence: "VDM"}))
+			Expect(delta.Updates[0].Timestamp).To(Equal(time.Unix(1553390539, 0).UTC()))
+		})
+		It("contains the values of the AIS message", func() {
+			values := signalKValues(delta)
+			Expect(values).To(HaveKeyWithValue("", map[string]string{"mmsi": "232008128"}))
+			Expect(values).To(HaveKeyWithValue("navigation.state", "motoring"))
+			Expect(values).To(HaveKeyWithValue("navigation.speedOverGround", BeNumerically("~", 6.8422, 0.0001)))
+			Expect(values).To(HaveKey("navigation.position"))
+		})
+	})
+	Context("when a sentence without Signal K values is converted", func() {
+		It("returns an error", func() {
+			toDelta("$PMTK001,604,3*32", SignalKConfig{})
+			Expect(err).To(MatchError(ErrNoSignalKValues))
+		})
+	})
+	Context("when a delta is encoded as JSON", func() {
+		It("uses the Signal K property names", func() {
+			parsed, parseErr := Parse("$SDDPT,0.5,0.5,0.1*54")
+			Expect(parseErr).ToNot(HaveOccurred())
+			delta, err = ToSignalKDeltaWithConfig(parsed, SignalKConfig{Now: func() time.Time { return time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC) }})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Marshal(delta)).To(MatchJSON(`{
+				"context": "vessels.self",
+				"updates": [{
+					"source": {"label": "nmea0183", "type": "NMEA0183", "talker": "SD", "sentence": "DPT"},
+					"timestamp": "2022-01-02T03:04:05Z",
+					"values": [
+						{"path": "environment.depth.belowSurface", "value": 1},
+						{"path": "environment.depth.belowTransducer", "value": 0.5}
+					]
+				}]
+			}`))
+		})
+	})
+})
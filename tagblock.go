@@ -1,21 +1,35 @@
 package nmea
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
+)
+
+const (
+	// tagBlockMillisecondThreshold is the smallest Time value that is interpreted as milliseconds
+	// instead of seconds, as seconds it would be a date in the year 5138
+	tagBlockMillisecondThreshold = 100000000000
+	// maxGroupTotal is the largest number of sentences of a tag block group, the total has at
+	// most two digits
+	maxGroupTotal = 99
 )
 
 // TagBlock type
 type TagBlock struct {
 	Valid         bool
 	InvalidReason string
-	Time          Int64  // TypeUnixTime unix timestamp (unit is likely to be s, but might be ms, YMMV), parameter: -c
-	RelativeTime  Int64  // TypeRelativeTime relative time, parameter: -r
-	Destination   String // TypeDestinationID destination identification 15 char max, parameter: -d
-	Grouping      String // TypeGrouping sentence grouping, parameter: -g
-	LineCount     Int64  // TypeLineCount line count, parameter: -n
-	Source        String // TypeSourceID source identification 15 char max, parameter: -s
-	Text          String // TypeTextString valid character string, parameter -t
+	Time          Int64             // TypeUnixTime unix timestamp (unit is likely to be s, but might be ms, YMMV), parameter: -c
+	RelativeTime  Int64             // TypeRelativeTime relative time, parameter: -r
+	Destination   String            // TypeDestinationID destination identification 15 char max, parameter: -d
+	Grouping      String            // TypeGrouping sentence grouping, parameter: -g
+	Group         Group             // The parsed sentence grouping
+	LineCount     Int64             // TypeLineCount line count, parameter: -n
+	Source        String            // TypeSourceID source identification 15 char max, parameter: -s
+	Text          String            // TypeTextString valid character string, parameter -t
+	Unknown       map[string]string // Unrecognised parameters keyed on the parameter name, nil if there are none
 }
 
 // NewInvalidTagblock creates an invalid TagBlock
@@ -33,18 +47,266 @@ func NewTagblock() TagBlock {
 		RelativeTime: NewInvalidInt64("not specified"),
 		Destination:  NewInvalidString("not specified"),
 		Grouping:     NewInvalidString("not specified"),
+		Group:        NewInvalidGroup("not specified"),
 		LineCount:    NewInvalidInt64("not specified"),
 		Source:       NewInvalidString("not specified"),
 		Text:         NewInvalidString("not specified"),
 	}
 }
 
+// Group is the sentence grouping of a tag block, e.g. g:1-3-1234 is the first sentence of group 1234 which consists of three sentences
+type Group struct {
+	Valid         bool
+	InvalidReason string
+	Sequence      int64 // The number of the sentence in the group, starting at 1
+	Total         int64 // The total number of sentences in the group
+	ID            int64 // The group identifier
+}
+
+// NewInvalidGroup creates an invalid Group
+func NewInvalidGroup(reason string) Group {
+	return Group{
+		InvalidReason: reason,
+	}
+}
+
+// NewGroup creates a valid Group
+func NewGroup(sequence int64, total int64, id int64) Group {
+	return Group{
+		Valid:    true,
+		Sequence: sequence,
+		Total:    total,
+		ID:       id,
+	}
+}
+
+// ParseGroup parses the sequence-total-id format of the g parameter of a tag block
+func ParseGroup(s string) Group {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return NewInvalidGroup(fmt.Sprintf("parse group: expected sequence-total-id format, got '%s'", s))
+	}
+	values := make([]int64, 3)
+	for i, part := range parts {
+		v := ParseInt64(part)
+		if !v.Valid {
+			return NewInvalidGroup(fmt.Sprintf("parse group: expected sequence-total-id format, got '%s'", s))
+		}
+		values[i] = v.Value
+	}
+	if values[1] < 1 || values[1] > maxGroupTotal {
+		return NewInvalidGroup(fmt.Sprintf("parse group: total %d is not in range (1, %d)", values[1], maxGroupTotal))
+	}
+	if values[0] < 1 || values[0] > values[1] {
+		return NewInvalidGroup(fmt.Sprintf("parse group: sequence %d is not in range (1, %d)", values[0], values[1]))
+	}
+	return NewGroup(values[0], values[1], values[2])
+}
+
+// TimeValue returns the Time parameter as a time.Time in UTC, the unit is detected from the magnitude
+// of the value, large values are interpreted as milliseconds and other values as seconds.
+// The zero time.Time is returned when the Time parameter is not valid.
+func (t TagBlock) TimeValue() time.Time {
+	if !t.Time.Valid {
+		return time.Time{}
+	}
+	if t.Time.Value >= tagBlockMillisecondThreshold {
+		return time.UnixMilli(t.Time.Value).UTC()
+	}
+	return time.Unix(t.Time.Value, 0).UTC()
+}
+
+// String formats a valid tag block including the checksum and the surrounding backslashes, the
+// unrecognised parameters are written after the known parameters in alphabetical order.
+// An empty string is returned for an invalid tag block or a tag block without parameters.
+func (t TagBlock) String() string {
+	if !t.Valid {
+		return ""
+	}
+	items := make([]string, 0, 7+len(t.Unknown))
+	if t.Time.Valid {
+		items = append(items, fmt.Sprintf("c:%d", t.Time.Value))
+	}
+	if t.Destination.Valid {
+		items = append(items, "d:"+t.Destination.Value)
+	}
+	if t.Grouping.Valid {
+		items = append(items, "g:"+t.Grouping.Value)
+	}
+	if t.LineCount.Valid {
+		items = append(items, fmt.Sprintf("n:%d", t.LineCount.Value))
+	}
+	if t.RelativeTime.Valid {
+		items = append(items, fmt.Sprintf("r:%d", t.RelativeTime.Value))
+	}
+	if t.Source.Valid {
+		items = append(items, "s:"+t.Source.Value)
+	}
+	if t.Text.Valid {
+		items = append(items, "t:"+t.Text.Value)
+	}
+	keys := make([]string, 0, len(t.Unknown))
+	for key := range t.Unknown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		items = append(items, key+":"+t.Unknown[key])
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	tags := strings.Join(items, ",")
+	return `\` + tags + ChecksumSep + Checksum(tags) + `\`
+}
+
+type tagBlockJSON struct {
+	Time         *int64            `json:"time,omitempty"`
+	RelativeTime *int64            `json:"relativeTime,omitempty"`
+	Destination  *string           `json:"destination,omitempty"`
+	Grouping     *string           `json:"grouping,omitempty"`
+	LineCount    *int64            `json:"lineCount,omitempty"`
+	Source       *string           `json:"source,omitempty"`
+	Text         *string           `json:"text,omitempty"`
+	Unknown      map[string]string `json:"unknown,omitempty"`
+}
+
+// MarshalJSON encodes the parameters of a valid tag block as an object, the parameters that are
+// not specified are left out. An invalid tag block is encoded as null.
+func (t TagBlock) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(tagBlockJSON{
+		Time:         validPointer(t.Time),
+		RelativeTime: validPointer(t.RelativeTime),
+		Destination:  validPointer(t.Destination),
+		Grouping:     validPointer(t.Grouping),
+		LineCount:    validPointer(t.LineCount),
+		Source:       validPointer(t.Source),
+		Text:         validPointer(t.Text),
+		Unknown:      t.Unknown,
+	})
+}
+
+// UnmarshalJSON decodes the parameters of a tag block, null results in an invalid tag block
+func (t *TagBlock) UnmarshalJSON(data []byte) error {
+	var v *tagBlockJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v == nil {
+		*t = TagBlock{}
+		return nil
+	}
+	*t = NewTagblock()
+	if v.Time != nil {
+		t.Time = NewInt64(*v.Time)
+	}
+	if v.RelativeTime != nil {
+		t.RelativeTime = NewInt64(*v.RelativeTime)
+	}
+	if v.Destination != nil {
+		t.Destination = NewString(*v.Destination)
+	}
+	if v.Grouping != nil {
+		t.Grouping = NewString(*v.Grouping)
+		t.Group = ParseGroup(*v.Grouping)
+	}
+	if v.LineCount != nil {
+		t.LineCount = NewInt64(*v.LineCount)
+	}
+	if v.Source != nil {
+		t.Source = NewString(*v.Source)
+	}
+	if v.Text != nil {
+		t.Text = NewString(*v.Text)
+	}
+	t.Unknown = v.Unknown
+	return nil
+}
+
+// validPointer returns a pointer to the value or nil when the value is invalid
+func validPointer[T any](v Value[T]) *T {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Value
+}
+
+// merge fills the parameters that are not valid with the parameters of the other tag block
+func (t TagBlock) merge(other TagBlock) TagBlock {
+	if !t.Time.Valid {
+		t.Time = other.Time
+	}
+	if !t.RelativeTime.Valid {
+		t.RelativeTime = other.RelativeTime
+	}
+	if !t.Destination.Valid {
+		t.Destination = other.Destination
+	}
+	if !t.Grouping.Valid {
+		t.Grouping = other.Grouping
+		t.Group = other.Group
+	}
+	if !t.LineCount.Valid {
+		t.LineCount = other.LineCount
+	}
+	if !t.Source.Valid {
+		t.Source = other.Source
+	}
+	if !t.Text.Valid {
+		t.Text = other.Text
+	}
+	for key, value := range other.Unknown {
+		if _, ok := t.Unknown[key]; ok {
+			continue
+		}
+		unknown := make(map[string]string, len(t.Unknown)+1)
+		for k, v := range t.Unknown {
+			unknown[k] = v
+		}
+		unknown[key] = value
+		t.Unknown = unknown
+	}
+	return t
+}
+
+// parseTagBlocks parses the consecutive tag blocks at the start of raw and returns them as a single
+// tag block together with the rest of raw. When a parameter is present in multiple tag blocks the
+// first value is used, the result is invalid when one of the tag blocks is invalid.
+func parseTagBlocks(raw string, allowEmptyChecksum bool, allowChecksumMismatch bool) (TagBlock, string) {
+	var tagBlock TagBlock
+	for i := 0; strings.HasPrefix(raw, `\`); i++ {
+		end := strings.Index(raw[1:], `\`)
+		if end == -1 {
+			break
+		}
+		t := parseTagBlock(raw[1:end+1], allowEmptyChecksum, allowChecksumMismatch)
+		raw = raw[end+2:]
+		switch {
+		case i == 0:
+			tagBlock = t
+		case !tagBlock.Valid:
+		case !t.Valid:
+			tagBlock = t
+		default:
+			tagBlock = tagBlock.merge(t)
+		}
+	}
+	return tagBlock, raw
+}
+
 // parseTagBlock adds support for tagblocks
 // https://gpsd.gitlab.io/gpsd/AIVDM.html#_nmea_tag_blocks
-func parseTagBlock(tags string) TagBlock {
+func parseTagBlock(tags string, allowEmptyChecksum bool, allowChecksumMismatch bool) TagBlock {
 	sumSepIndex := strings.Index(tags, ChecksumSep)
 	if sumSepIndex == -1 {
-		return NewInvalidTagblock("nmea: tagblock does not contain checksum separator")
+		if !allowEmptyChecksum {
+			return NewInvalidTagblock("nmea: tagblock does not contain checksum separator")
+		}
+		sumSepIndex = len(tags)
+		tags += ChecksumSep
 	}
 
 	var (
@@ -55,15 +317,18 @@ func parseTagBlock(tags string) TagBlock {
 	)
 
 	// Validate the checksum
-	if checksum != checksumRaw {
-		return NewInvalidTagblock(fmt.Sprintf("nmea: tagblock checksum mismatch [%s != %s]", checksum, checksumRaw))
+	if needsChecksumCheck(checksumRaw, allowEmptyChecksum, allowChecksumMismatch) {
+		if checksum != checksumRaw {
+			return NewInvalidTagblock(fmt.Sprintf("nmea: tagblock checksum mismatch [%s != %s]", checksum, checksumRaw))
+		}
 	}
 
-	items := strings.Split(tags[:sumSepIndex], ",")
+	items := strings.Split(fieldsRaw, ",")
 	for _, item := range items {
 		parts := strings.SplitN(item, ":", 2)
 		if len(parts) != 2 {
-			return NewInvalidTagblock(fmt.Sprintf("nmea: tagblock field is malformed (should be <key>:<value>) [%s]", item))
+			// skip malformed items instead of rejecting the other parameters
+			continue
 		}
 		key, value := parts[0], parts[1]
 		switch key {
@@ -73,6 +338,7 @@ func parseTagBlock(tags string) TagBlock {
 			tagBlock.Destination = NewString(value)
 		case "g": // Grouping
 			tagBlock.Grouping = NewString(value)
+			tagBlock.Group = ParseGroup(value)
 		case "n": // Line count
 			tagBlock.LineCount = ParseInt64(value)
 		case "r": // Relative time
@@ -81,6 +347,11 @@ func parseTagBlock(tags string) TagBlock {
 			tagBlock.Source = NewString(value)
 		case "t": // Text string
 			tagBlock.Text = NewString(value)
+		default:
+			if tagBlock.Unknown == nil {
+				tagBlock.Unknown = map[string]string{}
+			}
+			tagBlock.Unknown[key] = value
 		}
 	}
 	return tagBlock
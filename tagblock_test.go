@@ -1,6 +1,8 @@
 package nmea_test
 
 import (
+	"time"
+
 	. "github.com/munnik/go-nmea"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -24,6 +26,7 @@ var _ = Describe("Tagblock", func() {
 				Expect(rmc.TagBlock.Valid).To(BeTrue())
 				Expect(rmc.TagBlock.Time).To(Equal(NewInt64(1564827317)))
 				Expect(rmc.TagBlock.Source).To(Equal(NewInvalidString("not specified")))
+				Expect(rmc.TagBlock.Unknown).To(Equal(map[string]string{"x": "NorSat_1"}))
 			})
 		})
 		Context("with a tag block with an unknown tag and ten millisecond timestamp", func() {
@@ -44,17 +47,29 @@ var _ = Describe("Tagblock", func() {
 				Expect(rmc.TagBlock.RelativeTime).To(Equal(NewInt64(1553390539)))
 				Expect(rmc.TagBlock.Destination).To(Equal(NewString("ara")))
 				Expect(rmc.TagBlock.Grouping).To(Equal(NewString("bulk")))
+				Expect(rmc.TagBlock.Group).To(Equal(NewInvalidGroup("parse group: expected sequence-total-id format, got 'bulk'")))
 				Expect(rmc.TagBlock.Source).To(Equal(NewString("Satellite_1")))
 				Expect(rmc.TagBlock.Text).To(Equal(NewString("helloworld")))
 				Expect(rmc.TagBlock.LineCount).To(Equal(NewInt64(13)))
 			})
 		})
+		Context("with a tag block with a sentence group", func() {
+			It("returns a valid group", func() {
+				result, _ := Parse("\\g:1-2-73874,n:157036,s:r003669945,c:1241544035*4A\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				vdm := result.(VDMVDO)
+				Expect(vdm.TagBlock.Valid).To(BeTrue())
+				Expect(vdm.TagBlock.Grouping).To(Equal(NewString("1-2-73874")))
+				Expect(vdm.TagBlock.Group).To(Equal(NewGroup(1, 2, 73874)))
+			})
+		})
 		Context("with a tag block with an empty tag", func() {
-			It("returns a valid value", func() {
+			It("returns a valid value without the empty tag", func() {
 				result, _ := Parse("\\s:Satellite_1,,c:1564827317,r:1553390539,d:ara,g:bulk,n:13,t:helloworld*31\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03")
 				rmc := result.(RMC)
-				Expect(rmc.TagBlock.Valid).To(BeFalse())
-				Expect(rmc.TagBlock.InvalidReason).To(Equal("nmea: tagblock field is malformed (should be <key>:<value>) []"))
+				Expect(rmc.TagBlock.Valid).To(BeTrue())
+				Expect(rmc.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+				Expect(rmc.TagBlock.Time).To(Equal(NewInt64(1564827317)))
+				Expect(rmc.TagBlock.Unknown).To(BeNil())
 			})
 		})
 		Context("with a tag block with an invalid checksum", func() {
@@ -73,5 +88,103 @@ var _ = Describe("Tagblock", func() {
 				Expect(rmc.TagBlock.InvalidReason).To(Equal("nmea: tagblock does not contain checksum separator"))
 			})
 		})
+		Context("with a tag block without a checksum when empty checksums are allowed", func() {
+			It("returns a valid value", func() {
+				result, _ := Parse("\\s:Satellite_1,c:1564827317\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03", "AllowEmptyChecksum")
+				rmc := result.(RMC)
+				Expect(rmc.TagBlock.Valid).To(BeTrue())
+				Expect(rmc.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+				Expect(rmc.TagBlock.Time).To(Equal(NewInt64(1564827317)))
+			})
+		})
+		Context("with a tag block with an invalid checksum when checksum mismatches are allowed", func() {
+			It("returns a valid value", func() {
+				result, _ := Parse("\\s:Satellite_1,c:1564827317*00\\$GPRMC,001225,A,2832.1834,N,08101.0536,W,12,25,251211,1.2,E,A*03", "AllowChecksumMismatch")
+				rmc := result.(RMC)
+				Expect(rmc.TagBlock.Valid).To(BeTrue())
+				Expect(rmc.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+			})
+		})
+		Context("with multiple tag blocks", func() {
+			It("returns the combined tag block", func() {
+				result, err := Parse("\\g:1-2-73874*61\\\\s:r003669945,c:1241544035*79\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				Expect(err).ToNot(HaveOccurred())
+				vdm := result.(VDMVDO)
+				Expect(vdm.TagBlock.Valid).To(BeTrue())
+				Expect(vdm.TagBlock.Group).To(Equal(NewGroup(1, 2, 73874)))
+				Expect(vdm.TagBlock.Source).To(Equal(NewString("r003669945")))
+				Expect(vdm.TagBlock.Time).To(Equal(NewInt64(1241544035)))
+			})
+		})
+		Context("with multiple tag blocks of which one is invalid", func() {
+			It("returns an invalid tag block", func() {
+				result, err := Parse("\\g:1-2-73874*61\\\\s:r003669945,c:1241544035*00\\!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52")
+				Expect(err).ToNot(HaveOccurred())
+				vdm := result.(VDMVDO)
+				Expect(vdm.TagBlock.Valid).To(BeFalse())
+				Expect(vdm.TagBlock.InvalidReason).To(Equal("nmea: tagblock checksum mismatch [79 != 00]"))
+			})
+		})
+	})
+	Describe("Testing TimeValue method", func() {
+		Context("with a timestamp in seconds", func() {
+			It("returns the time", func() {
+				tagBlock := NewTagblock()
+				tagBlock.Time = NewInt64(1564827317)
+				Expect(tagBlock.TimeValue()).To(Equal(time.Date(2019, 8, 3, 10, 15, 17, 0, time.UTC)))
+			})
+		})
+		Context("with a timestamp in milliseconds", func() {
+			It("returns the time", func() {
+				tagBlock := NewTagblock()
+				tagBlock.Time = NewInt64(1564827317250)
+				Expect(tagBlock.TimeValue()).To(Equal(time.Date(2019, 8, 3, 10, 15, 17, 250000000, time.UTC)))
+			})
+		})
+		Context("without a timestamp", func() {
+			It("returns the zero time", func() {
+				Expect(NewTagblock().TimeValue().IsZero()).To(BeTrue())
+			})
+		})
+	})
+	Describe("Testing String method", func() {
+		Context("with a parsed tag block", func() {
+			It("returns the tag block with all parameters", func() {
+				result, _ := Parse("\\x:NorSat_1,s:Satellite_1,c:1564827317*12\\$GPHDT,123.456,T*32")
+				Expect(result.(HDT).TagBlock.String()).To(Equal("\\c:1564827317,s:Satellite_1,x:NorSat_1*12\\"))
+			})
+		})
+		Context("with an invalid tag block", func() {
+			It("returns an empty string", func() {
+				Expect(NewInvalidTagblock("invalid").String()).To(Equal(""))
+			})
+		})
+		Context("with a tag block without parameters", func() {
+			It("returns an empty string", func() {
+				Expect(NewTagblock().String()).To(Equal(""))
+			})
+		})
+	})
+	Describe("Testing ParseGroup function", func() {
+		Context("with a valid group", func() {
+			It("returns a valid value", func() {
+				Expect(ParseGroup("2-3-1234")).To(Equal(NewGroup(2, 3, 1234)))
+			})
+		})
+		Context("with a total larger than 99", func() {
+			It("returns an invalid group", func() {
+				Expect(ParseGroup("1-100000000000-1")).To(Equal(NewInvalidGroup("parse group: total 100000000000 is not in range (1, 99)")))
+			})
+		})
+		Context("with a sequence number larger than the total", func() {
+			It("returns an invalid value", func() {
+				Expect(ParseGroup("4-3-1234")).To(Equal(NewInvalidGroup("parse group: sequence 4 is not in range (1, 3)")))
+			})
+		})
+		Context("with a non numeric group", func() {
+			It("returns an invalid value", func() {
+				Expect(ParseGroup("1-x-1234")).To(Equal(NewInvalidGroup("parse group: expected sequence-total-id format, got '1-x-1234'")))
+			})
+		})
 	})
 })
@@ -48,3 +48,11 @@ func (s THS) GetTrueHeading() (float64, error) {
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the THS sentence into NMEA 0183 text
+func (s THS) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Heading)
+	e.StringField(s.Status)
+	return e.Encode()
+}
@@ -3,12 +3,13 @@ package nmea
 // Latitude / longitude representation.
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
 	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -53,7 +54,22 @@ func ParseLatLong(s string) Float64 {
 		return v
 	}
 
-	direction := string(s[len(s)-1:])
+	return latLongInRange(v, string(s[len(s)-1:]))
+}
+
+// parseLatLong parses the value and the direction of a coordinate, the GPS notation is
+// parsed without joining the value and the direction into a new string
+func parseLatLong(value string, direction string) Float64 {
+	if direction == North || direction == South || direction == East || direction == West {
+		if v := parseGPS(value, direction); v.Valid {
+			return latLongInRange(v, direction)
+		}
+	}
+	return ParseLatLong(value + " " + direction)
+}
+
+// latLongInRange checks the range of the latitude or longitude
+func latLongInRange(v Float64, direction string) Float64 {
 	if (direction == North || direction == South) && (v.Value < -90.0 || 90.0 < v.Value) {
 		return NewInvalidFloat64("latitude is not in range (-90, 90)")
 	} else if (direction == West || direction == East) && (v.Value < -180.0 || 180.0 < v.Value) {
@@ -70,8 +86,12 @@ func ParseGPS(s string) Float64 {
 	if len(parts) != 2 {
 		return NewInvalidFloat64(fmt.Sprintf("invalid format: %s", s))
 	}
-	dir := parts[1]
-	value, err := strconv.ParseFloat(parts[0], 64)
+	return parseGPS(parts[0], parts[1])
+}
+
+// parseGPS parses the value and the direction of a GPS/NMEA coordinate
+func parseGPS(s string, dir string) Float64 {
+	value, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return NewInvalidFloat64(fmt.Sprintf("parse error: %s", err.Error()))
 	}
@@ -208,6 +228,44 @@ func (t Time) String() string {
 	return fmt.Sprintf("%02d:%02d:%07.4f", t.Hour, t.Minute, seconds)
 }
 
+// Duration returns the time since midnight
+func (t Time) Duration() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Millisecond)*time.Millisecond
+}
+
+// MarshalJSON encodes a valid Time as a hh:mm:ss.ssss string and an invalid Time as null
+func (t Time) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes the hh:mm:ss.ssss string of a Time, null results in an invalid Time
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*t = NewInvalidTime("value is null")
+		return nil
+	}
+	var (
+		hour, minute int
+		second       float64
+	)
+	if _, err := fmt.Sscanf(*s, "%d:%d:%f", &hour, &minute, &second); err != nil {
+		return fmt.Errorf("nmea: expected hh:mm:ss.ssss format, got '%s'", *s)
+	}
+	whole, frac := math.Modf(second)
+	*t = NewTime(hour, minute, int(whole), int(math.Round(frac*1000)))
+	return nil
+}
+
 // timeRe is used to validate time strings
 var timeRe = regexp.MustCompile(`^\d{6}(\.\d*)?$`)
 
@@ -225,6 +283,15 @@ func ParseTime(s string) Time {
 	return NewTime(hour, minute, int(whole), int(math.Round(frac*1000)))
 }
 
+// FormatTime formats the Time in the hhmmss.ss format, a third decimal is only used
+// when the milliseconds require it.
+func FormatTime(t Time) string {
+	if t.Millisecond%10 == 0 {
+		return fmt.Sprintf("%02d%02d%02d.%02d", t.Hour, t.Minute, t.Second, t.Millisecond/10)
+	}
+	return fmt.Sprintf("%02d%02d%02d.%03d", t.Hour, t.Minute, t.Second, t.Millisecond)
+}
+
 // Date type
 type Date struct {
 	Valid         bool
@@ -257,6 +324,44 @@ func (d Date) String() string {
 	return fmt.Sprintf("%02d/%02d/%02d", d.DD, d.MM, d.YY)
 }
 
+// Year returns the year of the Date, a two digit year is interpreted with DefaultPivotYear, see
+// DateConfig.Year
+func (d Date) Year() int {
+	return DateConfig{}.Year(d)
+}
+
+// At combines the Date with the Time into a time.Time in UTC, an error is returned when the
+// Date or the Time is invalid, see DateConfig.Time
+func (d Date) At(t Time) (time.Time, error) {
+	return DateConfig{}.Time(d, t)
+}
+
+// MarshalJSON encodes a valid Date as a dd/mm/yy string and an invalid Date as null
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes the dd/mm/yy string of a Date, null results in an invalid Date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*d = NewInvalidDate("value is null")
+		return nil
+	}
+	var dd, mm, yy int
+	if _, err := fmt.Sscanf(*s, "%d/%d/%d", &dd, &mm, &yy); err != nil {
+		return fmt.Errorf("nmea: expected dd/mm/yy format, got '%s'", *s)
+	}
+	*d = NewDate(yy, mm, dd)
+	return nil
+}
+
 // dateRe is used to validate date strings
 var dateRe = regexp.MustCompile(`^\d{6}$`)
 
@@ -271,6 +376,11 @@ func ParseDate(s string) Date {
 	return NewDate(yy, mm, dd)
 }
 
+// FormatDate formats the Date in the ddmmyy format
+func FormatDate(d Date) string {
+	return fmt.Sprintf("%02d%02d%02d", d.DD, d.MM, d.YY%100)
+}
+
 // LatDir returns the latitude direction symbol
 func LatDir(l float64) string {
 	if l < 0.0 {
@@ -288,33 +398,16 @@ func LonDir(l float64) string {
 }
 
 // Float64 type
-type Float64 struct {
-	Valid         bool
-	InvalidReason string
-	Value         float64
-}
+type Float64 = Value[float64]
 
 // NewInvalidFloat64 creates an invalid Float64
 func NewInvalidFloat64(reason string) Float64 {
-	return Float64{
-		InvalidReason: reason,
-	}
+	return NewInvalidValue[float64](reason)
 }
 
 // NewFloat64 creates a valid Float64
 func NewFloat64(v float64) Float64 {
-	return Float64{
-		Valid: true,
-		Value: v,
-	}
-}
-
-// GetValue returns the float64 value or an error if valid is false
-func (v Float64) GetValue() (float64, error) {
-	if v.Valid {
-		return v.Value, nil
-	}
-	return 0, errors.New(v.InvalidReason)
+	return NewValue(v)
 }
 
 // ParseFloat64 parses a string and creates a Float64, if the string can't be parsed an invalid Float64 is returned
@@ -327,33 +420,16 @@ func ParseFloat64(s string) Float64 {
 }
 
 // Int64 type
-type Int64 struct {
-	Valid         bool
-	InvalidReason string
-	Value         int64
-}
+type Int64 = Value[int64]
 
 // NewInvalidInt64 creates an invalid Int64
 func NewInvalidInt64(reason string) Int64 {
-	return Int64{
-		InvalidReason: reason,
-	}
+	return NewInvalidValue[int64](reason)
 }
 
 // NewInt64 creates a valid Int64
 func NewInt64(v int64) Int64 {
-	return Int64{
-		Valid: true,
-		Value: v,
-	}
-}
-
-// GetValue returns the int64 value or an error if valid is false
-func (v Int64) GetValue() (int64, error) {
-	if v.Valid {
-		return v.Value, nil
-	}
-	return 0, errors.New(v.InvalidReason)
+	return NewValue(v)
 }
 
 // ParseInt64 parses a string and creates a Int64, if the string can't be parsed an invalid Int64 is returned
@@ -366,33 +442,16 @@ func ParseInt64(s string) Int64 {
 }
 
 // String type
-type String struct {
-	Valid         bool
-	InvalidReason string
-	Value         string
-}
+type String = Value[string]
 
 // NewInvalidString creates an invalid String
 func NewInvalidString(reason string) String {
-	return String{
-		InvalidReason: reason,
-	}
+	return NewInvalidValue[string](reason)
 }
 
 // NewString creates a valid String
 func NewString(v string) String {
-	return String{
-		Valid: true,
-		Value: v,
-	}
-}
-
-// GetValue returns the string value or an error if valid is false
-func (v String) GetValue() (string, error) {
-	if v.Valid {
-		return v.Value, nil
-	}
-	return "", errors.New(v.InvalidReason)
+	return NewValue(v)
 }
 
 // StringList type
@@ -416,3 +475,28 @@ func NewStringList(v []String) StringList {
 		Values: v,
 	}
 }
+
+// GetValue returns the values or an error if valid is false
+func (v StringList) GetValue() ([]String, error) {
+	return v.ToValue().GetValue()
+}
+
+// ToValue returns the StringList as a Value of the list of strings
+func (v StringList) ToValue() Value[[]String] {
+	return Value[[]String]{Valid: v.Valid, InvalidReason: v.InvalidReason, Value: v.Values}
+}
+
+// MarshalJSON encodes a valid StringList as an array and an invalid StringList as null
+func (v StringList) MarshalJSON() ([]byte, error) {
+	return v.ToValue().MarshalJSON()
+}
+
+// UnmarshalJSON decodes the array of a StringList, null results in an invalid StringList
+func (v *StringList) UnmarshalJSON(data []byte) error {
+	var value Value[[]String]
+	if err := value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*v = StringList{Valid: value.Valid, InvalidReason: value.InvalidReason, Values: value.Value}
+	return nil
+}
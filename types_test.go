@@ -1,6 +1,8 @@
 package nmea_test
 
 import (
+	"time"
+
 	. "github.com/munnik/go-nmea"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -204,6 +206,29 @@ var _ = Describe("Types", func() {
 				Expect(value.String()).To(Equal("01/02/03"))
 			})
 		})
+		Context("when getting the duration of a time", func() {
+			It("returns the time since midnight", func() {
+				Expect(NewTime(1, 2, 3, 4).Duration()).To(Equal(time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond))
+			})
+		})
+		Context("when getting the year of a date", func() {
+			It("returns the year of a two digit year", func() {
+				Expect(NewDate(21, 4, 23).Year()).To(Equal(2021))
+				Expect(NewDate(94, 6, 13).Year()).To(Equal(1994))
+			})
+			It("returns a four digit year as is", func() {
+				Expect(NewDate(1979, 6, 13).Year()).To(Equal(1979))
+			})
+		})
+		Context("when combining a date with a time", func() {
+			It("returns the date and time in UTC", func() {
+				Expect(NewDate(21, 4, 23).At(NewTime(11, 45, 9, 300))).To(Equal(time.Date(2021, 4, 23, 11, 45, 9, 300000000, time.UTC)))
+			})
+			It("returns an error when the time is invalid", func() {
+				_, err := NewDate(21, 4, 23).At(NewInvalidTime(""))
+				Expect(err).To(HaveOccurred())
+			})
+		})
 		Context("when getting the direction of a latitude", func() {
 			It("returns the correct direction", func() {
 				Expect(LatDir(50.0)).To(Equal(North))
@@ -0,0 +1,8 @@
+package nmea
+
+// Unknown is a sentence without a parser, it is only returned when unknown sentences are passed
+// through, see ParseConfig.UnknownPassthrough. The fields, the tag block and the raw text are kept
+// so the sentence can be routed, logged, filtered and encoded again.
+type Unknown struct {
+	BaseSentence
+}
@@ -0,0 +1,57 @@
+package nmea_test
+
+import (
+	"strings"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unknown", func() {
+	const raw = "\\c:1553390539,s:Satellite_1*0E\\$IIXDR,C,19.52,C,TempAir*19"
+	Context("when unknown sentences are passed through", func() {
+		var (
+			result Sentence
+			err    error
+		)
+		BeforeEach(func() {
+			result, err = Parse(raw, "UnknownPassthrough")
+		})
+		It("returns an unknown sentence with all fields", func() {
+			Expect(err).ToNot(HaveOccurred())
+			unknown, ok := result.(Unknown)
+			Expect(ok).To(BeTrue())
+			Expect(unknown.Prefix()).To(Equal("IIXDR"))
+			Expect(unknown.Talker).To(Equal("II"))
+			Expect(unknown.Fields).To(Equal([]string{"C", "19.52", "C", "TempAir"}))
+			Expect(unknown.TagBlock.Source).To(Equal(NewString("Satellite_1")))
+			Expect(unknown.String()).To(Equal("$IIXDR,C,19.52,C,TempAir*19"))
+		})
+		It("encodes the sentence with the tag block", func() {
+			Expect(Encode(result)).To(Equal(raw))
+		})
+	})
+	Context("when unknown sentences are not passed through", func() {
+		It("returns an error", func() {
+			_, err := Parse(raw)
+			Expect(err).To(MatchError("nmea: sentence prefix 'IIXDR' not supported"))
+		})
+	})
+	Context("when a known sentence is passed through", func() {
+		It("returns the parsed sentence", func() {
+			result, err := Parse("$GPHDT,123.456,T*32", "UnknownPassthrough")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeAssignableToTypeOf(HDT{}))
+		})
+	})
+	Context("when scanning", func() {
+		It("returns the unknown sentences", func() {
+			scanner := NewScanner(strings.NewReader("$IIXDR,C,19.52,C,TempAir*19\r\n$GPHDT,123.456,T*32\r\n"), "UnknownPassthrough")
+			Expect(scanner.Scan()).To(BeTrue())
+			Expect(scanner.Frame().Sentence).To(BeAssignableToTypeOf(Unknown{}))
+			Expect(scanner.Scan()).To(BeTrue())
+			Expect(scanner.Frame().Sentence).To(BeAssignableToTypeOf(HDT{}))
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package nmea
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Value is a value of a sentence field, a value is invalid when the field is empty or
+// can not be parsed, the reason is kept in InvalidReason
+type Value[T any] struct {
+	Valid         bool
+	InvalidReason string
+	Value         T
+}
+
+// NewValue creates a valid Value
+func NewValue[T any](v T) Value[T] {
+	return Value[T]{
+		Valid: true,
+		Value: v,
+	}
+}
+
+// NewInvalidValue creates an invalid Value
+func NewInvalidValue[T any](reason string) Value[T] {
+	return Value[T]{
+		InvalidReason: reason,
+	}
+}
+
+// GetValue returns the value or an error if valid is false
+func (v Value[T]) GetValue() (T, error) {
+	if v.Valid {
+		return v.Value, nil
+	}
+	var zero T
+	return zero, errors.New(v.InvalidReason)
+}
+
+// OrElse returns the value or the fallback if valid is false
+func (v Value[T]) OrElse(fallback T) T {
+	if v.Valid {
+		return v.Value
+	}
+	return fallback
+}
+
+// Map applies f to a valid value, an invalid value keeps the reason it is invalid
+func Map[T, U any](v Value[T], f func(T) U) Value[U] {
+	if !v.Valid {
+		return NewInvalidValue[U](v.InvalidReason)
+	}
+	return NewValue(f(v.Value))
+}
+
+// MarshalJSON encodes a valid value as the JSON of the value and an invalid value as null
+func (v Value[T]) MarshalJSON() ([]byte, error) {
+	if !v.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.Value)
+}
+
+// UnmarshalJSON decodes the JSON of the value, null results in an invalid value
+func (v *Value[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*v = NewInvalidValue[T]("value is null")
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*v = NewValue(value)
+	return nil
+}
+
+// MarshalText encodes a valid value as text, an invalid value is empty
+func (v Value[T]) MarshalText() ([]byte, error) {
+	if !v.Valid {
+		return []byte{}, nil
+	}
+	switch value := any(v.Value).(type) {
+	case encoding.TextMarshaler:
+		return value.MarshalText()
+	case string:
+		return []byte(value), nil
+	case float64:
+		return strconv.AppendFloat(nil, value, 'f', -1, 64), nil
+	case int64:
+		return strconv.AppendInt(nil, value, 10), nil
+	}
+	return []byte(fmt.Sprint(v.Value)), nil
+}
+
+// UnmarshalText decodes the text of the value, empty text results in an invalid value
+func (v *Value[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = NewInvalidValue[T]("value is empty")
+		return nil
+	}
+	var value T
+	switch p := any(&value).(type) {
+	case encoding.TextUnmarshaler:
+		if err := p.UnmarshalText(text); err != nil {
+			return err
+		}
+	case *string:
+		*p = string(text)
+	case *float64:
+		f, err := strconv.ParseFloat(string(text), 64)
+		if err != nil {
+			return fmt.Errorf("nmea: %w", err)
+		}
+		*p = f
+	case *int64:
+		i, err := strconv.ParseInt(string(text), 10, 64)
+		if err != nil {
+			return fmt.Errorf("nmea: %w", err)
+		}
+		*p = i
+	default:
+		return fmt.Errorf("nmea: can not unmarshal text into a %T", value)
+	}
+	*v = NewValue(value)
+	return nil
+}
@@ -0,0 +1,93 @@
+package nmea_test
+
+import (
+	"encoding/json"
+	"strconv"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// double is a generic helper over sentence fields
+func double[T int64 | float64](v Value[T]) Value[T] {
+	return Map(v, func(x T) T { return 2 * x })
+}
+
+var _ = Describe("Value", func() {
+	Context("when the value is valid", func() {
+		v := NewValue(1.5)
+		It("returns the value", func() {
+			Expect(v.GetValue()).To(Equal(1.5))
+			Expect(v.OrElse(0)).To(Equal(1.5))
+		})
+		It("is the same as the alias", func() {
+			Expect(v).To(Equal(NewFloat64(1.5)))
+			Expect(NewValue[int64](3)).To(Equal(Int64{Valid: true, Value: 3}))
+		})
+		It("maps the value", func() {
+			Expect(double(v)).To(Equal(NewFloat64(3)))
+			Expect(double(NewInt64(3))).To(Equal(NewInt64(6)))
+			Expect(Map(NewInt64(42), func(i int64) string { return strconv.FormatInt(i, 16) })).To(Equal(NewString("2a")))
+		})
+	})
+	Context("when the value is invalid", func() {
+		v := NewInvalidValue[float64]("no value")
+		It("returns an error", func() {
+			_, err := v.GetValue()
+			Expect(err).To(MatchError("no value"))
+			Expect(v.OrElse(-1)).To(Equal(-1.0))
+		})
+		It("keeps the reason when mapped", func() {
+			Expect(Map(v, func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) })).To(Equal(NewInvalidString("no value")))
+		})
+	})
+	Context("when marshalling to JSON", func() {
+		type fields struct {
+			Speed Float64
+			Count Int64
+			Name  String
+		}
+		It("encodes an invalid value as null", func() {
+			data, err := json.Marshal(fields{Speed: NewFloat64(6.4), Count: NewInvalidInt64("empty"), Name: NewString("ADELANTE")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"Speed":6.4,"Count":null,"Name":"ADELANTE"}`))
+		})
+		It("decodes null as an invalid value", func() {
+			var f fields
+			Expect(json.Unmarshal([]byte(`{"Speed":6.4,"Count":null,"Name":"ADELANTE"}`), &f)).To(Succeed())
+			Expect(f.Speed).To(Equal(NewFloat64(6.4)))
+			Expect(f.Count.Valid).To(BeFalse())
+			Expect(f.Name).To(Equal(NewString("ADELANTE")))
+		})
+		It("returns an error for the wrong type", func() {
+			var f fields
+			Expect(json.Unmarshal([]byte(`{"Speed":"fast"}`), &f)).ToNot(Succeed())
+		})
+	})
+	Context("when marshalling to text", func() {
+		It("encodes the value", func() {
+			Expect(NewFloat64(0.1).MarshalText()).To(Equal([]byte("0.1")))
+			Expect(NewInt64(-7).MarshalText()).To(Equal([]byte("-7")))
+			Expect(NewString("A").MarshalText()).To(Equal([]byte("A")))
+			Expect(NewInvalidFloat64("empty").MarshalText()).To(BeEmpty())
+		})
+		It("decodes the value", func() {
+			var f Float64
+			Expect(f.UnmarshalText([]byte("12.5"))).To(Succeed())
+			Expect(f).To(Equal(NewFloat64(12.5)))
+			Expect(f.UnmarshalText([]byte{})).To(Succeed())
+			Expect(f.Valid).To(BeFalse())
+			Expect(f.UnmarshalText([]byte("x"))).ToNot(Succeed())
+			var b Value[bool]
+			Expect(b.UnmarshalText([]byte("true"))).To(MatchError("nmea: can not unmarshal text into a bool"))
+		})
+	})
+	Context("when using a StringList", func() {
+		It("returns the values", func() {
+			Expect(NewStringList([]String{NewString("A")}).GetValue()).To(Equal([]String{NewString("A")}))
+			_, err := NewInvalidStringList("empty").GetValue()
+			Expect(err).To(MatchError("empty"))
+		})
+	})
+})
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/BertoldVdb/go-ais"
-	"github.com/BertoldVdb/go-ais/aisnmea"
 	"github.com/martinlindhe/unit"
 )
 
@@ -30,128 +29,6 @@ const (
 	cogNotAvailable                    ais.Field10         = 360
 )
 
-var navigationStatuses []string = []string{
-	"motoring",
-	"anchored",
-	"not under command",
-	"restricted maneuverability",
-	"constrained by draft",
-	"moored",
-	"aground",
-	"fishing",
-	"sailing",
-	"hazardous material high speed",
-	"hazardous material wing in ground",
-	"reserved for future use",
-	"reserved for future use",
-	"reserved for future use",
-	"ais-sart",
-	"default",
-}
-
-var vesselTypes []string = []string{
-	"Not available (default)",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Reserved for future use",
-	"Wing in ground (WIG), All ships of this type",
-	"Wing in ground (WIG), Hazardous category A",
-	"Wing in ground (WIG), Hazardous category B",
-	"Wing in ground (WIG), Hazardous category C",
-	"Wing in ground (WIG), Hazardous category D",
-	"Wing in ground (WIG), Reserved for future use",
-	"Wing in ground (WIG), Reserved for future use",
-	"Wing in ground (WIG), Reserved for future use",
-	"Wing in ground (WIG), Reserved for future use",
-	"Wing in ground (WIG), Reserved for future use",
-	"Fishing",
-	"Towing",
-	"Towing: length exceeds 200m or breadth exceeds 25m",
-	"Dredging or underwater ops",
-	"Diving ops",
-	"Military ops",
-	"Sailing",
-	"Pleasure Craft",
-	"Reserved",
-	"Reserved",
-	"High speed craft (HSC), All ships of this type",
-	"High speed craft (HSC), Hazardous category A",
-	"High speed craft (HSC), Hazardous category B",
-	"High speed craft (HSC), Hazardous category C",
-	"High speed craft (HSC), Hazardous category D",
-	"High speed craft (HSC), Reserved for future use",
-	"High speed craft (HSC), Reserved for future use",
-	"High speed craft (HSC), Reserved for future use",
-	"High speed craft (HSC), Reserved for future use",
-	"High speed craft (HSC), No additional information",
-	"Pilot Vessel",
-	"Search and Rescue vessel",
-	"Tug",
-	"Port Tender",
-	"Anti-pollution equipment",
-	"Law Enforcement",
-	"Spare - Local Vessel",
-	"Spare - Local Vessel",
-	"Medical Transport",
-	"Noncombatant ship according to RR Resolution No. 18",
-	"Passenger, All ships of this type",
-	"Passenger, Hazardous category A",
-	"Passenger, Hazardous category B",
-	"Passenger, Hazardous category C",
-	"Passenger, Hazardous category D",
-	"Passenger, Reserved for future use",
-	"Passenger, Reserved for future use",
-	"Passenger, Reserved for future use",
-	"Passenger, Reserved for future use",
-	"Passenger, No additional information",
-	"Cargo, All ships of this type",
-	"Cargo, Hazardous category A",
-	"Cargo, Hazardous category B",
-	"Cargo, Hazardous category C",
-	"Cargo, Hazardous category D",
-	"Cargo, Reserved for future use",
-	"Cargo, Reserved for future use",
-	"Cargo, Reserved for future use",
-	"Cargo, Reserved for future use",
-	"Cargo, No additional information",
-	"Tanker, All ships of this type",
-	"Tanker, Hazardous category A",
-	"Tanker, Hazardous category B",
-	"Tanker, Hazardous category C",
-	"Tanker, Hazardous category D",
-	"Tanker, Reserved for future use",
-	"Tanker, Reserved for future use",
-	"Tanker, Reserved for future use",
-	"Tanker, Reserved for future use",
-	"Tanker, No additional information",
-	"Other Type, All ships of this type",
-	"Other Type, Hazardous category A",
-	"Other Type, Hazardous category B",
-	"Other Type, Hazardous category C",
-	"Other Type, Hazardous category D",
-	"Other Type, Reserved for future use",
-	"Other Type, Reserved for future use",
-	"Other Type, Reserved for future use",
-	"Other Type, Reserved for future use",
-	"Other Type, No additional information",
-}
-
 // VDMVDO is a format used to encapsulate generic binary payloads. It is most commonly used
 // with AIS data.
 // https://gpsd.gitlab.io/gpsd/AIVDM.html
@@ -162,18 +39,21 @@ type VDMVDO struct {
 	MessageID      Int64
 	Channel        String
 	Payload        []byte
+	Fragments      []BaseSentence // All fragments of a message assembled by the AISAssembler
 	ais.Packet
 }
 
 var (
-	nmeaCodec *aisnmea.NMEACodec
-	aisCodec  *ais.Codec
+	aisCodec *ais.Codec
+	// defaultAISAssembler assembles the fragments of multi-fragment messages that are parsed without
+	// an AISAssembler in the ParseConfig, the fragments are kept apart per tag block source, talker
+	// and channel
+	defaultAISAssembler = NewAISAssembler(DefaultAISFragmentTimeout)
 )
 
 func init() {
 	aisCodec = ais.CodecNew(false, false)
 	aisCodec.DropSpace = true
-	nmeaCodec = aisnmea.NMEACodecNew(aisCodec)
 }
 
 // newVDMVDO constructor
@@ -183,18 +63,36 @@ func newVDMVDO(s BaseSentence) (VDMVDO, error) {
 		BaseSentence:   s,
 		NumFragments:   p.Int64(0, "number of fragments"),
 		FragmentNumber: p.Int64(1, "fragment number"),
-		MessageID:      p.Int64(2, "sequence number"),
-		Channel:        p.String(3, "channel ID"),
-		Payload:        p.SixBitASCIIArmour(4, int(p.Int64(5, "number of padding bits").Value), "payload"),
 	}
-	result, err := nmeaCodec.ParseSentence(s.String())
-	if err != nil {
+	// The fragment fields are required to decode the message
+	if !m.NumFragments.Valid && len(s.Fields) > 0 {
+		p.SetFieldErr(0, "number of fragments", s.Fields[0])
+	}
+	if !m.FragmentNumber.Valid && len(s.Fields) > 1 {
+		p.SetFieldErr(1, "fragment number", s.Fields[1])
+	}
+	m.MessageID = p.Int64(2, "sequence number")
+	m.Channel = p.String(3, "channel ID")
+	m.Payload = p.SixBitASCIIArmour(4, int(p.Int64(5, "number of padding bits").Value), "payload")
+	if err := p.Err(); err != nil {
 		return m, err
 	}
-	if result != nil {
-		m.Packet = result.Packet
+	// The packet is decoded from the decoded payload, the last fragment of a multi-fragment
+	// message contains the packet of the whole message
+	if m.NumFragments.Value == 1 {
+		if len(m.Payload) > 0 {
+			m.Packet = aisCodec.DecodePacket(m.Payload)
+		}
+	} else if !s.skipFragmentAssembly {
+		assembler := s.aisAssembler
+		if assembler == nil {
+			assembler = defaultAISAssembler
+		}
+		if assembled, ok, _ := assembler.addAt(m, time.Now(), true); ok {
+			m.Packet = assembled.Packet
+		}
 	}
-	return m, p.Err()
+	return m, nil
 }
 
 func extractNumber(binaryData []byte, offset int, length int) (uint64, error) {
@@ -274,14 +172,22 @@ func (s VDMVDO) GetMMSI() (string, error) {
 	return fmt.Sprintf("%d", s.Packet.GetHeader().UserID), nil
 }
 
-// GetNavigationStatus retrieves the navigation status from the sentence
+// GetNavigationStatus retrieves the name of the navigation status from the sentence
 func (s VDMVDO) GetNavigationStatus() (string, error) {
-	if positionReport, ok := s.Packet.(ais.PositionReport); ok && positionReport.Valid {
-		return navigationStatuses[positionReport.NavigationalStatus], nil
+	if status, err := s.GetAISNavigationStatus(); err == nil && int(status) < len(navigationStatusInfo) {
+		return navigationStatusInfo[status].name, nil
 	}
 	return "", fmt.Errorf("value is unavailable")
 }
 
+// GetAISNavigationStatus retrieves the navigation status from the sentence
+func (s VDMVDO) GetAISNavigationStatus() (AISNavigationStatus, error) {
+	if positionReport, ok := s.Packet.(ais.PositionReport); ok && positionReport.Valid {
+		return AISNavigationStatus(positionReport.NavigationalStatus), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
 // GetVesselBeam retrieves the beam of the vessel from the sentence
 func (s VDMVDO) GetVesselBeam() (float64, error) {
 	if binaryBroadcastMessage, ok := s.Packet.(ais.BinaryBroadcastMessage); ok && binaryBroadcastMessage.Valid && binaryBroadcastMessage.ApplicationID.DesignatedAreaCode == 200 && binaryBroadcastMessage.ApplicationID.FunctionIdentifier == 10 {
@@ -330,20 +236,24 @@ func (s VDMVDO) GetVesselName() (string, error) {
 	return "", fmt.Errorf("value is unavailable")
 }
 
-// GetVesselType retrieves the type of the vessel from the sentence
+// GetVesselType retrieves the name of the type of the vessel from the sentence
 func (s VDMVDO) GetVesselType() (string, error) {
-	vesselTypeIndex := -1
+	if shipType, err := s.GetAISShipType(); err == nil && shipType < 100 {
+		return shipType.name(), nil
+	}
+	return "", fmt.Errorf("value is unavailable")
+}
+
+// GetAISShipType retrieves the type of ship and cargo of the vessel from the sentence
+func (s VDMVDO) GetAISShipType() (AISShipType, error) {
 	if staticDataReport, ok := s.Packet.(ais.StaticDataReport); ok && staticDataReport.Valid && staticDataReport.ReportB.Valid {
-		vesselTypeIndex = int(staticDataReport.ReportB.ShipType)
+		return AISShipType(staticDataReport.ReportB.ShipType), nil
 	} else if shipStaticData, ok := s.Packet.(ais.ShipStaticData); ok && shipStaticData.Valid {
-		vesselTypeIndex = int(shipStaticData.Type)
+		return AISShipType(shipStaticData.Type), nil
 	} else if positionReport, ok := s.Packet.(ais.ExtendedClassBPositionReport); ok {
-		vesselTypeIndex = int(positionReport.Type)
-	}
-	if vesselTypeIndex >= 0 && vesselTypeIndex < len(vesselTypes) {
-		return vesselTypes[vesselTypeIndex], nil
+		return AISShipType(positionReport.Type), nil
 	}
-	return "", fmt.Errorf("value is unavailable")
+	return 0, fmt.Errorf("value is unavailable")
 }
 
 // GetRateOfTurn retrieves the rate of turn from the sentence
@@ -486,3 +396,15 @@ func (s VDMVDO) GetETA() (time.Time, error) {
 	}
 	return time.Unix(0, 0), fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the VDMVDO sentence into NMEA 0183 text
+func (s VDMVDO) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Encapsulated()
+	e.Int64(s.NumFragments)
+	e.Int64(s.FragmentNumber)
+	e.Int64(s.MessageID)
+	e.StringField(s.Channel)
+	e.SixBitASCIIArmour(s.Payload, "payload")
+	return e.Encode()
+}
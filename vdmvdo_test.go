@@ -91,6 +91,21 @@ var _ = Describe("VDMVDO", func() {
 				}))
 			})
 		})
+		Context("a multipart sentence of which the first fragment is received again", func() {
+			BeforeEach(func() {
+				raws = []string{
+					"!AIVDM,2,1,1,B,000000000000,0*17",
+					"!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11",
+					"!AIVDM,2,2,1,B,Q0C@00000000000,2*44",
+				}
+			})
+			It("returns no errors", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("decodes the packet of the last first fragment", func() {
+				Expect(parsed.GetMMSI()).To(Equal("244660797"))
+			})
+		})
 		Context("a valid  sentence with an empty payload", func() {
 			BeforeEach(func() {
 				raws = []string{
@@ -323,6 +338,7 @@ var _ = Describe("VDMVDO", func() {
 			})
 			It("returns a valid navigation status", func() {
 				Expect(parsed.GetNavigationStatus()).To(Equal("moored"))
+				Expect(parsed.GetAISNavigationStatus()).To(Equal(NavigationStatusMoored))
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetVesselBeam()
@@ -397,6 +413,10 @@ var _ = Describe("VDMVDO", func() {
 			})
 			It("returns a valid vessel type", func() {
 				Expect(parsed.GetVesselType()).To(Equal("Cargo, No additional information"))
+				shipType, err := parsed.GetAISShipType()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(shipType.Group()).To(Equal(ShipTypeCargo))
+				Expect(shipType.Identifier()).To(Equal("cargo-no-additional-information"))
 			})
 			It("returns an error", func() {
 				_, err := parsed.GetRateOfTurn()
@@ -535,3 +555,38 @@ var _ = Describe("VDMVDO", func() {
 		})
 	})
 })
+
+var _ = Describe("VDMVDO fragments of different sources", func() {
+	withSource := func(source string, raw string) string {
+		tagBlock := "s:" + source
+		return "\\" + tagBlock + "*" + Checksum(tagBlock) + "\\" + raw
+	}
+	It("assembles the fragments of each source with Parse", func() {
+		first := "!AIVDM,2,1,1,B,53aDr?H000010CS7OH04@Dh4q@D000000000001?1QR75u8kP05iDRiC,0*11"
+		second := "!AIVDM,2,2,1,B,Q0C@00000000000,2*44"
+		for _, raw := range []string{withSource("receiver_1", first), withSource("receiver_2", first)} {
+			_, err := Parse(raw)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		for _, source := range []string{"receiver_1", "receiver_2"} {
+			s, err := Parse(withSource(source, second))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s.(VDMVDO).GetMMSI()).To(Equal("244660797"))
+		}
+	})
+})
+
+var _ = Describe("VDMVDO vessel types", func() {
+	DescribeTable("returns the name of the vessel type",
+		func(shipType int, expected string) {
+			s := VDMVDO{Packet: ais.ShipStaticData{Valid: true, Type: uint8(shipType)}}
+			Expect(s.GetVesselType()).To(Equal(expected))
+		},
+		Entry("not available", 0, "Not available (default)"),
+		Entry("reserved", 1, "Reserved for future use"),
+		Entry("reserved after law enforcement", 38, "Reserved"),
+		Entry("reserved before passenger", 39, "Reserved"),
+		Entry("spare", 56, "Spare - Local Vessel"),
+		Entry("cargo with hazardous category A", 71, "Cargo, Hazardous category A"),
+	)
+})
@@ -0,0 +1,323 @@
+package nmea
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultVesselStateMaxAge is the default time after which a value of the VesselState is stale
+	DefaultVesselStateMaxAge = 10 * time.Minute
+	// DefaultSourcePriorityTimeout is the default time a source with a lower priority waits before it
+	// replaces the value of a source with a higher priority
+	DefaultSourcePriorityTimeout = 5 * time.Second
+
+	// SignalKVersion is the version of the Signal K specification of the full model
+	SignalKVersion = "1.7.0"
+	// signalKSelfID is the identifier of the own vessel when VesselStateConfig.Self is empty
+	signalKSelfID = "self"
+	// signalKVesselsPrefix is the prefix of the contexts of vessels
+	signalKVesselsPrefix = "vessels."
+)
+
+// VesselStateConfig contains the options of a VesselState
+type VesselStateConfig struct {
+	// Self is the identifier of the own vessel, e.g. urn:mrn:imo:mmsi:244123456, self when it is
+	// empty. AIS messages of the own MMSI update the own vessel when it is an MMSI URN.
+	Self string
+	// SourcePriority are the sources in order of priority, the first source has the highest
+	// priority. A source is matched on its label and talker (e.g. nmea0183.GP), its label or its
+	// talker. Sources that are not in the list have the lowest priority.
+	SourcePriority []string
+	// PriorityTimeout is the time after which a source with a lower priority replaces the value of
+	// a source with a higher priority, DefaultSourcePriorityTimeout is used when it is 0 or less
+	PriorityTimeout time.Duration
+	// MaxAge is the time after which a value is stale and removed, DefaultVesselStateMaxAge is
+	// used when it is 0 or less
+	MaxAge time.Duration
+	// SignalK contains the options of the conversion of the sentences to Signal K
+	SignalK SignalKConfig
+}
+
+// VesselStateValue is the latest value of a Signal K path
+type VesselStateValue struct {
+	Value     interface{}   // The value in SI units
+	Timestamp time.Time     // The timestamp of the update of the value
+	Source    SignalKSource // The source of the value
+	Received  time.Time     // The time the value was added to the VesselState
+	priority  int
+}
+
+// SignalKFull is the Signal K full model of the vessels, see
+// https://signalk.org/specification/latest/doc/data_model.html
+type SignalKFull struct {
+	Version string                            `json:"version"`
+	Self    string                            `json:"self"`
+	Vessels map[string]map[string]interface{} `json:"vessels"`
+	Sources map[string]map[string]interface{} `json:"sources"`
+}
+
+// VesselState keeps the latest value of each Signal K path of the own vessel and of the vessels
+// that are received by AIS. The values of a path are taken from the source with the highest
+// priority, a source with a lower priority is used when the source with the higher priority has
+// not updated the path within the priority timeout. Values older than the max age are stale, they
+// are not returned and they are removed periodically while values are added or by Expire.
+// A VesselState is safe for concurrent use by multiple goroutines.
+type VesselState struct {
+	config     VesselStateConfig
+	mu         sync.RWMutex
+	contexts   map[string]map[string]VesselStateValue
+	nextExpire time.Time // The time of the next removal of the stale values by AddDeltaAt
+}
+
+// NewVesselState constructor
+func NewVesselState(config VesselStateConfig) *VesselState {
+	if config.Self == "" {
+		config.Self = signalKSelfID
+	}
+	if config.PriorityTimeout <= 0 {
+		config.PriorityTimeout = DefaultSourcePriorityTimeout
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = DefaultVesselStateMaxAge
+	}
+	return &VesselState{
+		config:   config,
+		contexts: map[string]map[string]VesselStateValue{},
+	}
+}
+
+// Add adds the values of a sentence received now, see AddAt
+func (v *VesselState) Add(s Sentence) error {
+	return v.AddAt(s, time.Now())
+}
+
+// AddAt adds the values of a sentence received at the given time, the sentence is converted with
+// ToSignalKDeltaWithConfig. ErrNoSignalKValues is returned when the sentence has no values.
+func (v *VesselState) AddAt(s Sentence, at time.Time) error {
+	config := v.config.SignalK
+	if config.Now == nil {
+		config.Now = func() time.Time { return at }
+	}
+	delta, err := ToSignalKDeltaWithConfig(s, config)
+	if err != nil {
+		return err
+	}
+	return v.AddDeltaAt(delta, at)
+}
+
+// AddDeltaAt adds the values of a Signal K delta message received at the given time, the values
+// of the empty path, e.g. the MMSI and the name, are added under the name of their property.
+// An error is returned when the context is not a vessel.
+func (v *VesselState) AddDeltaAt(delta SignalKDelta, at time.Time) error {
+	id, err := v.vesselID(delta.Context)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// the stale values are removed once per max age instead of on every add, they are not
+	// returned in the meantime
+	if !at.Before(v.nextExpire) {
+		v.expire(at)
+		v.nextExpire = at.Add(v.config.MaxAge)
+	}
+	values, ok := v.contexts[id]
+	if !ok {
+		values = map[string]VesselStateValue{}
+		v.contexts[id] = values
+	}
+	for _, update := range delta.Updates {
+		value := VesselStateValue{
+			Timestamp: update.Timestamp,
+			Source:    update.Source,
+			Received:  at,
+			priority:  v.priority(update.Source),
+		}
+		for _, pathValue := range update.Values {
+			if properties, ok := pathValue.Value.(map[string]string); ok && pathValue.Path == "" {
+				for name, property := range properties {
+					value.Value = property
+					v.update(values, name, value)
+				}
+				continue
+			}
+			value.Value = pathValue.Value
+			v.update(values, pathValue.Path, value)
+		}
+	}
+	return nil
+}
+
+// update replaces the value of the path unless the current value is from a source with a higher
+// priority that is not timed out
+func (v *VesselState) update(values map[string]VesselStateValue, path string, value VesselStateValue) {
+	if current, ok := values[path]; ok && current.priority < value.priority && value.Received.Sub(current.Received) <= v.config.PriorityTimeout {
+		return
+	}
+	values[path] = value
+}
+
+// Get returns the latest value of the path of the vessel now, see GetAt
+func (v *VesselState) Get(context, path string) (VesselStateValue, bool) {
+	return v.GetAt(context, path, time.Now())
+}
+
+// GetAt returns the latest value of the path of the vessel that is not stale at the given time,
+// the vessel is identified by its context, e.g. vessels.self or vessels.urn:mrn:imo:mmsi:244123456
+func (v *VesselState) GetAt(context, path string, at time.Time) (VesselStateValue, bool) {
+	id, err := v.vesselID(context)
+	if err != nil {
+		return VesselStateValue{}, false
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	value, ok := v.contexts[id][path]
+	if !ok || v.stale(value, at) {
+		return VesselStateValue{}, false
+	}
+	return value, true
+}
+
+// Contexts returns the contexts of the vessels in alphabetical order
+func (v *VesselState) Contexts() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	result := make([]string, 0, len(v.contexts))
+	for id := range v.contexts {
+		result = append(result, signalKVesselsPrefix+id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Expire removes the values that are older than the max age at the given time, vessels without
+// values are removed as well
+func (v *VesselState) Expire(at time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.expire(at)
+}
+
+func (v *VesselState) expire(at time.Time) {
+	for id, values := range v.contexts {
+		for path, value := range values {
+			if v.stale(value, at) {
+				delete(values, path)
+			}
+		}
+		if len(values) == 0 {
+			delete(v.contexts, id)
+		}
+	}
+}
+
+// stale checks if the value is older than the max age at the given time
+func (v *VesselState) stale(value VesselStateValue, at time.Time) bool {
+	return at.Sub(value.Received) > v.config.MaxAge
+}
+
+// Snapshot returns the Signal K full model of the vessels now, see SnapshotAt
+func (v *VesselState) Snapshot() SignalKFull {
+	return v.SnapshotAt(time.Now())
+}
+
+// SnapshotAt returns the Signal K full model of the vessels and the sources of their values that
+// are not stale at the given time, vessels without values are left out
+func (v *VesselState) SnapshotAt(at time.Time) SignalKFull {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	full := SignalKFull{
+		Version: SignalKVersion,
+		Self:    signalKVesselsPrefix + v.config.Self,
+		Vessels: map[string]map[string]interface{}{},
+		Sources: map[string]map[string]interface{}{},
+	}
+	for id, values := range v.contexts {
+		vessel := map[string]interface{}{}
+		for path, value := range values {
+			if v.stale(value, at) {
+				continue
+			}
+			if !strings.Contains(path, ".") {
+				vessel[path] = value.Value
+			} else {
+				setSignalKPath(vessel, path, map[string]interface{}{
+					"value":     value.Value,
+					"timestamp": value.Timestamp,
+					"$source":   value.Source.Label + "." + value.Source.Talker,
+				})
+			}
+			addSignalKSource(full.Sources, value)
+		}
+		if len(vessel) > 0 {
+			full.Vessels[id] = vessel
+		}
+	}
+	return full
+}
+
+// MarshalJSON encodes the Signal K full model of the VesselState now, see Snapshot
+func (v *VesselState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Snapshot())
+}
+
+// vesselID returns the identifier of the vessel of the context, the own vessel and the MMSI of the
+// own vessel are both identified by the identifier of the own vessel
+func (v *VesselState) vesselID(context string) (string, error) {
+	if !strings.HasPrefix(context, signalKVesselsPrefix) {
+		return "", fmt.Errorf("nmea: unsupported Signal K context '%s'", context)
+	}
+	if context == SignalKSelf {
+		return v.config.Self, nil
+	}
+	return strings.TrimPrefix(context, signalKVesselsPrefix), nil
+}
+
+// priority returns the index of the source in the source priority, a lower index is a higher priority
+func (v *VesselState) priority(source SignalKSource) int {
+	for i, s := range v.config.SourcePriority {
+		if s == source.Label+"."+source.Talker || s == source.Label || s == source.Talker {
+			return i
+		}
+	}
+	return len(v.config.SourcePriority)
+}
+
+// setSignalKPath sets the leaf of the dotted path in the nested objects
+func setSignalKPath(object map[string]interface{}, path string, leaf interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := object[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			object[key] = child
+		}
+		object = child
+	}
+	object[keys[len(keys)-1]] = leaf
+}
+
+// addSignalKSource adds the source of the value to the sources with the latest timestamp of the sentence
+func addSignalKSource(sources map[string]map[string]interface{}, value VesselStateValue) {
+	label, ok := sources[value.Source.Label]
+	if !ok {
+		label = map[string]interface{}{"label": value.Source.Label, "type": value.Source.Type}
+		sources[value.Source.Label] = label
+	}
+	talker, ok := label[value.Source.Talker].(map[string]interface{})
+	if !ok {
+		talker = map[string]interface{}{"talker": value.Source.Talker, "sentences": map[string]time.Time{}}
+		label[value.Source.Talker] = talker
+	}
+	sentences := talker["sentences"].(map[string]time.Time)
+	if value.Timestamp.After(sentences[value.Source.Sentence]) {
+		sentences[value.Source.Sentence] = value.Timestamp
+	}
+}
@@ -0,0 +1,165 @@
+package nmea_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/munnik/go-nmea"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VesselState", func() {
+	var (
+		state *VesselState
+		start time.Time
+	)
+	add := func(raw string, at time.Time) {
+		parsed, err := Parse(raw)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, state.AddAt(parsed, at)).To(Succeed())
+	}
+	BeforeEach(func() {
+		start = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		state = NewVesselState(VesselStateConfig{
+			Self:           "urn:mrn:imo:mmsi:232008128",
+			SourcePriority: []string{"GP", "nmea0183"},
+			MaxAge:         time.Minute,
+		})
+	})
+
+	Context("when sentences of the own vessel are added", func() {
+		BeforeEach(func() {
+			add("$GPHDT,123.456,T*32", start)
+			add("$SDDPT,0.5,0.5,0.1*54", start)
+		})
+		It("keeps the latest value of each path", func() {
+			value, ok := state.GetAt(SignalKSelf, "navigation.headingTrue", start)
+			Expect(ok).To(BeTrue())
+			Expect(value.Value).To(BeNumerically("~", 2.1547, 0.0001))
+			Expect(value.Timestamp).To(Equal(start))
+			Expect(value.Source).To(Equal(SignalKSource{Label: "nmea0183", Type: "NMEA0183", Talker: "GP", Sentence: "HDT"}))
+
+			add("$GPHDT,100.0,T*34", start.Add(time.Second))
+			value, ok = state.GetAt(SignalKSelf, "navigation.headingTrue", start.Add(time.Second))
+			Expect(ok).To(BeTrue())
+			Expect(value.Value).To(BeNumerically("~", 1.7453, 0.0001))
+		})
+		It("has a single context", func() {
+			Expect(state.Contexts()).To(Equal([]string{"vessels.urn:mrn:imo:mmsi:232008128"}))
+		})
+	})
+	Context("when AIS messages are added", func() {
+		BeforeEach(func() {
+			add("!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55", start)
+			add("!AIVDM,1,1,,A,13M@ah0025QdPDTCOl`K6`nV00Sv,0*52", start)
+		})
+		It("keeps a context per MMSI", func() {
+			Expect(state.Contexts()).To(HaveLen(2))
+			Expect(state.Contexts()).To(ContainElement("vessels.urn:mrn:imo:mmsi:232008128"))
+		})
+		It("adds the MMSI as a property of the vessel", func() {
+			value, ok := state.GetAt("vessels.urn:mrn:imo:mmsi:232008128", "mmsi", start)
+			Expect(ok).To(BeTrue())
+			Expect(value.Value).To(Equal("232008128"))
+		})
+		It("merges the messages of the own MMSI with the own vessel", func() {
+			_, ok := state.GetAt(SignalKSelf, "navigation.position", start)
+			Expect(ok).To(BeTrue())
+		})
+	})
+	Context("when several sources provide the same path", func() {
+		BeforeEach(func() {
+			add("$GPHDT,123.456,T*32", start)
+			add("$INTHS,100.0,A*26", start.Add(time.Second))
+		})
+		It("keeps the value of the source with the highest priority", func() {
+			value, _ := state.GetAt(SignalKSelf, "navigation.headingTrue", start.Add(time.Second))
+			Expect(value.Source.Talker).To(Equal("GP"))
+		})
+		It("uses the source with the lower priority after the priority timeout", func() {
+			add("$INTHS,100.0,A*26", start.Add(DefaultSourcePriorityTimeout+time.Second))
+			value, _ := state.GetAt(SignalKSelf, "navigation.headingTrue", start.Add(DefaultSourcePriorityTimeout+time.Second))
+			Expect(value.Source.Talker).To(Equal("IN"))
+		})
+		It("replaces the value of a source with a lower priority", func() {
+			add("$INTHS,100.0,A*26", start.Add(DefaultSourcePriorityTimeout+time.Second))
+			add("$GPHDT,123.456,T*32", start.Add(DefaultSourcePriorityTimeout+2*time.Second))
+			value, _ := state.GetAt(SignalKSelf, "navigation.headingTrue", start.Add(DefaultSourcePriorityTimeout+2*time.Second))
+			Expect(value.Source.Talker).To(Equal("GP"))
+		})
+	})
+	Context("when values are stale", func() {
+		It("removes the values and the vessels without values", func() {
+			add("!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55", start)
+			add("$GPHDT,123.456,T*32", start.Add(30*time.Second))
+			state.Expire(start.Add(61 * time.Second))
+			Expect(state.Contexts()).To(Equal([]string{"vessels.urn:mrn:imo:mmsi:232008128"}))
+			state.Expire(start.Add(91 * time.Second))
+			Expect(state.Contexts()).To(BeEmpty())
+		})
+		It("does not return the stale values before they are removed", func() {
+			add("!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55", start)
+			add("$GPHDT,123.456,T*32", start.Add(30*time.Second))
+			_, ok := state.GetAt("vessels.urn:mrn:imo:mmsi:244670316", "mmsi", start.Add(61*time.Second))
+			Expect(ok).To(BeFalse())
+			_, ok = state.GetAt(SignalKSelf, "navigation.headingTrue", start.Add(61*time.Second))
+			Expect(ok).To(BeTrue())
+			Expect(state.SnapshotAt(start.Add(61 * time.Second)).Vessels).To(HaveLen(1))
+			Expect(state.Contexts()).To(HaveLen(2))
+		})
+		It("removes the stale values while values are added", func() {
+			add("$GPHDT,123.456,T*32", start)
+			add("!AIVDM,1,1,,A,13aGt0PP0jPN@9fMPKVDJgwfR>`<,0*55", start.Add(time.Second))
+			add("$GPHDT,123.456,T*32", start.Add(60*time.Second))
+			add("$GPHDT,123.456,T*32", start.Add(90*time.Second))
+			Expect(state.Contexts()).To(HaveLen(2))
+			add("$GPHDT,123.456,T*32", start.Add(120*time.Second))
+			Expect(state.Contexts()).To(Equal([]string{"vessels.urn:mrn:imo:mmsi:232008128"}))
+		})
+	})
+	Context("when a delta of an unsupported context is added", func() {
+		It("returns an error", func() {
+			err := state.AddDeltaAt(SignalKDelta{Context: "atons.urn:mrn:imo:mmsi:992446000"}, start)
+			Expect(err).To(MatchError("nmea: unsupported Signal K context 'atons.urn:mrn:imo:mmsi:992446000'"))
+		})
+	})
+	Context("when a sentence without Signal K values is added", func() {
+		It("returns an error", func() {
+			parsed, err := Parse("$PMTK001,604,3*32")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.AddAt(parsed, start)).To(MatchError(ErrNoSignalKValues))
+		})
+	})
+	Context("when the state is snapshotted", func() {
+		It("returns the Signal K full model", func() {
+			add("$GPHDT,123.456,T*32", start)
+			add("$SDDPT,0.5,0.5,*7B", start)
+			Expect(json.Marshal(state.SnapshotAt(start))).To(MatchJSON(`{
+				"version": "1.7.0",
+				"self": "vessels.urn:mrn:imo:mmsi:232008128",
+				"vessels": {
+					"urn:mrn:imo:mmsi:232008128": {
+						"navigation": {
+							"headingTrue": {"value": 2.1547136813421197, "timestamp": "2022-01-02T03:04:05Z", "$source": "nmea0183.GP"}
+						},
+						"environment": {
+							"depth": {
+								"belowSurface": {"value": 1, "timestamp": "2022-01-02T03:04:05Z", "$source": "nmea0183.SD"},
+								"belowTransducer": {"value": 0.5, "timestamp": "2022-01-02T03:04:05Z", "$source": "nmea0183.SD"}
+							}
+						}
+					}
+				},
+				"sources": {
+					"nmea0183": {
+						"label": "nmea0183",
+						"type": "NMEA0183",
+						"GP": {"talker": "GP", "sentences": {"HDT": "2022-01-02T03:04:05Z"}},
+						"SD": {"talker": "SD", "sentences": {"DPT": "2022-01-02T03:04:05Z"}}
+					}
+				}
+			}`))
+		})
+	})
+})
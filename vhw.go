@@ -16,8 +16,8 @@ type VHW struct {
 	BaseSentence
 	TrueHeading            Float64
 	MagneticHeading        Float64
-	SpeedThroughWaterKnots Float64
-	SpeedThroughWaterKPH   Float64
+	SpeedThroughWaterKnots Speed
+	SpeedThroughWaterKPH   Speed
 }
 
 // newVHW constructor
@@ -28,8 +28,8 @@ func newVHW(s BaseSentence) (VHW, error) {
 		BaseSentence:           s,
 		TrueHeading:            p.Float64(0, "true heading"),
 		MagneticHeading:        p.Float64(2, "magnetic heading"),
-		SpeedThroughWaterKnots: p.Float64(4, "speed through water in knots"),
-		SpeedThroughWaterKPH:   p.Float64(6, "speed through water in kilometers per hour"),
+		SpeedThroughWaterKnots: p.Speed(4, 5, "speed through water in knots", SpeedUnitKnots),
+		SpeedThroughWaterKPH:   p.Speed(6, 7, "speed through water in kilometers per hour", SpeedUnitKilometersPerHour),
 	}, p.Err()
 }
 
@@ -51,11 +51,24 @@ func (s VHW) GetTrueHeading() (float64, error) {
 
 // GetSpeedThroughWater retrieves the speed through water from the sentence
 func (s VHW) GetSpeedThroughWater() (float64, error) {
-	if v, err := s.SpeedThroughWaterKPH.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.KilometersPerHour).MetersPerSecond(), nil
-	}
-	if v, err := s.SpeedThroughWaterKnots.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
+	for _, speed := range []Speed{s.SpeedThroughWaterKPH, s.SpeedThroughWaterKnots} {
+		if v, err := speed.GetSpeed(); err == nil {
+			return v.MetersPerSecond(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the VHW sentence into NMEA 0183 text
+func (s VHW) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.TrueHeading)
+	e.RawField("T")
+	e.Float64(s.MagneticHeading)
+	e.RawField("M")
+	e.Float64(s.SpeedThroughWaterKnots.Float64)
+	e.RawField(SpeedUnitKnots)
+	e.Float64(s.SpeedThroughWaterKPH.Float64)
+	e.RawField(SpeedUnitKilometersPerHour)
+	return e.Encode()
+}
@@ -34,8 +34,8 @@ var _ = Describe("VHW", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
 					"TrueHeading":            Equal(NewFloat64(45.0)),
 					"MagneticHeading":        Equal(NewFloat64(43.0)),
-					"SpeedThroughWaterKnots": Equal(NewFloat64(3.5)),
-					"SpeedThroughWaterKPH":   Equal(NewFloat64(6.4)),
+					"SpeedThroughWaterKnots": Equal(NewSpeed(3.5, SpeedUnitKnots)),
+					"SpeedThroughWaterKPH":   Equal(NewSpeed(6.4, SpeedUnitKilometersPerHour)),
 				}))
 			})
 		})
@@ -56,8 +56,8 @@ var _ = Describe("VHW", func() {
 			parsed = VHW{
 				TrueHeading:            NewFloat64(TrueDirectionDegrees),
 				MagneticHeading:        NewFloat64(MagneticDirectionDegrees),
-				SpeedThroughWaterKPH:   NewFloat64(SpeedThroughWaterKPH),
-				SpeedThroughWaterKnots: NewFloat64(SpeedThroughWaterKnots),
+				SpeedThroughWaterKPH:   NewSpeed(SpeedThroughWaterKPH, SpeedUnitKilometersPerHour),
+				SpeedThroughWaterKnots: NewSpeed(SpeedThroughWaterKnots, SpeedUnitKnots),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -103,7 +103,7 @@ var _ = Describe("VHW", func() {
 		})
 		Context("when having a struct with missing speed over ground kph", func() {
 			JustBeforeEach(func() {
-				parsed.SpeedThroughWaterKPH = NewInvalidFloat64("")
+				parsed.SpeedThroughWaterKPH = NewInvalidSpeed("")
 			})
 			It("returns a valid true heading", func() {
 				Expect(parsed.GetTrueHeading()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -117,7 +117,7 @@ var _ = Describe("VHW", func() {
 		})
 		Context("when having a struct with missing speed over ground knots", func() {
 			JustBeforeEach(func() {
-				parsed.SpeedThroughWaterKnots = NewInvalidFloat64("")
+				parsed.SpeedThroughWaterKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid true heading", func() {
 				Expect(parsed.GetTrueHeading()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -131,8 +131,8 @@ var _ = Describe("VHW", func() {
 		})
 		Context("when having a struct with missing speed over ground kph and knots", func() {
 			JustBeforeEach(func() {
-				parsed.SpeedThroughWaterKPH = NewInvalidFloat64("")
-				parsed.SpeedThroughWaterKnots = NewInvalidFloat64("")
+				parsed.SpeedThroughWaterKPH = NewInvalidSpeed("")
+				parsed.SpeedThroughWaterKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid true heading", func() {
 				Expect(parsed.GetTrueHeading()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -17,8 +17,8 @@ type VTG struct {
 	BaseSentence
 	TrueTrack        Float64
 	MagneticTrack    Float64
-	GroundSpeedKnots Float64
-	GroundSpeedKPH   Float64
+	GroundSpeedKnots Speed
+	GroundSpeedKPH   Speed
 }
 
 // newVTG parses the VTG sentence into this struct.
@@ -30,8 +30,8 @@ func newVTG(s BaseSentence) (VTG, error) {
 		BaseSentence:     s,
 		TrueTrack:        p.Float64(0, "true track"),
 		MagneticTrack:    p.Float64(2, "magnetic track"),
-		GroundSpeedKnots: p.Float64(4, "ground speed (knots)"),
-		GroundSpeedKPH:   p.Float64(6, "ground speed (km/h)"),
+		GroundSpeedKnots: p.Speed(4, 5, "ground speed (knots)", SpeedUnitKnots),
+		GroundSpeedKPH:   p.Speed(6, 7, "ground speed (km/h)", SpeedUnitKilometersPerHour),
 	}, p.Err()
 }
 
@@ -53,11 +53,24 @@ func (s VTG) GetMagneticCourseOverGround() (float64, error) {
 
 // GetSpeedOverGround retrieves the speed over ground from the sentence
 func (s VTG) GetSpeedOverGround() (float64, error) {
-	if v, err := s.GroundSpeedKPH.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.KilometersPerHour).MetersPerSecond(), nil
-	}
-	if v, err := s.GroundSpeedKnots.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
+	for _, speed := range []Speed{s.GroundSpeedKPH, s.GroundSpeedKnots} {
+		if v, err := speed.GetSpeed(); err == nil {
+			return v.MetersPerSecond(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the VTG sentence into NMEA 0183 text
+func (s VTG) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.TrueTrack)
+	e.RawField("T")
+	e.Float64(s.MagneticTrack)
+	e.RawField("M")
+	e.Float64(s.GroundSpeedKnots.Float64)
+	e.RawField(SpeedUnitKnots)
+	e.Float64(s.GroundSpeedKPH.Float64)
+	e.RawField(SpeedUnitKilometersPerHour)
+	return e.Encode()
+}
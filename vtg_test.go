@@ -34,8 +34,8 @@ var _ = Describe("VTG", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
 					"TrueTrack":        Equal(NewFloat64(45.5)),
 					"MagneticTrack":    Equal(NewFloat64(67.5)),
-					"GroundSpeedKnots": Equal(NewFloat64(30.45)),
-					"GroundSpeedKPH":   Equal(NewFloat64(56.40)),
+					"GroundSpeedKnots": Equal(NewSpeed(30.45, SpeedUnitKnots)),
+					"GroundSpeedKPH":   Equal(NewSpeed(56.40, SpeedUnitKilometersPerHour)),
 				}))
 			})
 		})
@@ -56,8 +56,8 @@ var _ = Describe("VTG", func() {
 			parsed = VTG{
 				TrueTrack:        NewFloat64(TrueDirectionDegrees),
 				MagneticTrack:    NewFloat64(MagneticDirectionDegrees),
-				GroundSpeedKPH:   NewFloat64(SpeedOverGroundKPH),
-				GroundSpeedKnots: NewFloat64(SpeedOverGroundKnots),
+				GroundSpeedKPH:   NewSpeed(SpeedOverGroundKPH, SpeedUnitKilometersPerHour),
+				GroundSpeedKnots: NewSpeed(SpeedOverGroundKnots, SpeedUnitKnots),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -103,7 +103,7 @@ var _ = Describe("VTG", func() {
 		})
 		Context("when having a struct with missing speed over ground kph", func() {
 			JustBeforeEach(func() {
-				parsed.GroundSpeedKPH = NewInvalidFloat64("")
+				parsed.GroundSpeedKPH = NewInvalidSpeed("")
 			})
 			It("returns a valid true course over ground", func() {
 				Expect(parsed.GetTrueCourseOverGround()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -117,7 +117,7 @@ var _ = Describe("VTG", func() {
 		})
 		Context("when having a struct with missing speed over ground knots", func() {
 			JustBeforeEach(func() {
-				parsed.GroundSpeedKnots = NewInvalidFloat64("")
+				parsed.GroundSpeedKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid true course over ground", func() {
 				Expect(parsed.GetTrueCourseOverGround()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -131,8 +131,8 @@ var _ = Describe("VTG", func() {
 		})
 		Context("when having a struct with missing speed over ground kph and knots", func() {
 			JustBeforeEach(func() {
-				parsed.GroundSpeedKPH = NewInvalidFloat64("")
-				parsed.GroundSpeedKnots = NewInvalidFloat64("")
+				parsed.GroundSpeedKPH = NewInvalidSpeed("")
+				parsed.GroundSpeedKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid true course over ground", func() {
 				Expect(parsed.GetTrueCourseOverGround()).To(BeNumerically("~", TrueDirectionRadians, 0.00001))
@@ -29,9 +29,9 @@ type VWR struct {
 	BaseSentence
 	Angle                        Float64
 	LeftRightOfBow               String
-	WindSpeedInKnots             Float64
-	WindSpeedInMetersPerSecond   Float64
-	WindSpeedInKilometersPerHour Float64
+	WindSpeedInKnots             Speed
+	WindSpeedInMetersPerSecond   Speed
+	WindSpeedInKilometersPerHour Speed
 }
 
 // newVWR constructor
@@ -42,9 +42,9 @@ func newVWR(s BaseSentence) (VWR, error) {
 		BaseSentence:                 s,
 		Angle:                        p.Float64(0, "Angle"),
 		LeftRightOfBow:               p.EnumString(1, "LeftRightOfBow", LeftOfBow, RightOfBow),
-		WindSpeedInKnots:             p.Float64(2, "WindSpeedInKnots"),
-		WindSpeedInMetersPerSecond:   p.Float64(4, "WindSpeedInMetersPerSecond"),
-		WindSpeedInKilometersPerHour: p.Float64(6, "WindSpeedInKilometersPerHour"),
+		WindSpeedInKnots:             p.Speed(2, 3, "WindSpeedInKnots", SpeedUnitKnots),
+		WindSpeedInMetersPerSecond:   p.Speed(4, 5, "WindSpeedInMetersPerSecond", SpeedUnitMetersPerSecond),
+		WindSpeedInKilometersPerHour: p.Speed(6, 7, "WindSpeedInKilometersPerHour", SpeedUnitKilometersPerHour),
 	}
 	return m, p.Err()
 }
@@ -64,14 +64,24 @@ func (s VWR) GetRelativeWindDirection() (float64, error) {
 
 // GetWindSpeed retrieves wind speed from the sentence
 func (s VWR) GetWindSpeed() (float64, error) {
-	if v, err := s.WindSpeedInMetersPerSecond.GetValue(); err == nil {
-		return v, nil
-	}
-	if v, err := s.WindSpeedInKilometersPerHour.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.KilometersPerHour).MetersPerSecond(), nil
-	}
-	if v, err := s.WindSpeedInKnots.GetValue(); err == nil {
-		return (unit.Speed(v) * unit.Knot).MetersPerSecond(), nil
+	for _, speed := range []Speed{s.WindSpeedInMetersPerSecond, s.WindSpeedInKilometersPerHour, s.WindSpeedInKnots} {
+		if v, err := speed.GetSpeed(); err == nil {
+			return v.MetersPerSecond(), nil
+		}
 	}
 	return 0, fmt.Errorf("value is unavailable")
 }
+
+// Encode serializes the VWR sentence into NMEA 0183 text
+func (s VWR) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Float64(s.Angle)
+	e.StringField(s.LeftRightOfBow)
+	e.Float64(s.WindSpeedInKnots.Float64)
+	e.RawField(SpeedUnitKnots)
+	e.Float64(s.WindSpeedInMetersPerSecond.Float64)
+	e.RawField(SpeedUnitMetersPerSecond)
+	e.Float64(s.WindSpeedInKilometersPerHour.Float64)
+	e.RawField(SpeedUnitKilometersPerHour)
+	return e.Encode()
+}
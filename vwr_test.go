@@ -34,9 +34,9 @@ var _ = Describe("VWR", func() {
 				Expect(parsed).To(MatchFields(IgnoreExtras, Fields{
 					"Angle":                        Equal(NewFloat64(45)),
 					"LeftRightOfBow":               Equal(NewString(LeftOfBow)),
-					"WindSpeedInKnots":             Equal(NewFloat64(12.6)),
-					"WindSpeedInMetersPerSecond":   Equal(NewFloat64(6.5)),
-					"WindSpeedInKilometersPerHour": Equal(NewFloat64(23.3)),
+					"WindSpeedInKnots":             Equal(NewSpeed(12.6, SpeedUnitKnots)),
+					"WindSpeedInMetersPerSecond":   Equal(NewSpeed(6.5, SpeedUnitMetersPerSecond)),
+					"WindSpeedInKilometersPerHour": Equal(NewSpeed(23.3, SpeedUnitKilometersPerHour)),
 				}))
 			})
 		})
@@ -57,9 +57,9 @@ var _ = Describe("VWR", func() {
 			parsed = VWR{
 				Angle:                        NewFloat64(RelativeDirectionDegrees),
 				LeftRightOfBow:               NewString(RightOfBow),
-				WindSpeedInKnots:             NewFloat64(SpeedOverGroundKnots),
-				WindSpeedInMetersPerSecond:   NewFloat64(SpeedOverGroundMPS),
-				WindSpeedInKilometersPerHour: NewFloat64(SpeedOverGroundKPH),
+				WindSpeedInKnots:             NewSpeed(SpeedOverGroundKnots, SpeedUnitKnots),
+				WindSpeedInMetersPerSecond:   NewSpeed(SpeedOverGroundMPS, SpeedUnitMetersPerSecond),
+				WindSpeedInKilometersPerHour: NewSpeed(SpeedOverGroundKPH, SpeedUnitKilometersPerHour),
 			}
 		})
 		Context("when having a complete struct", func() {
@@ -83,7 +83,7 @@ var _ = Describe("VWR", func() {
 		})
 		Context("when having a struct with missing wind speed in meters per second", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInMetersPerSecond = NewInvalidFloat64("")
+				parsed.WindSpeedInMetersPerSecond = NewInvalidSpeed("")
 			})
 			It("returns a valid relative wind direction", func() {
 				Expect(parsed.GetRelativeWindDirection()).To(BeNumerically("~", RelativeDirectionRadians, 0.00001))
@@ -94,7 +94,7 @@ var _ = Describe("VWR", func() {
 		})
 		Context("when having a struct with missing wind speed in kilometer per hour", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInKilometersPerHour = NewInvalidFloat64("")
+				parsed.WindSpeedInKilometersPerHour = NewInvalidSpeed("")
 			})
 			It("returns a valid relative wind direction", func() {
 				Expect(parsed.GetRelativeWindDirection()).To(BeNumerically("~", RelativeDirectionRadians, 0.00001))
@@ -105,7 +105,7 @@ var _ = Describe("VWR", func() {
 		})
 		Context("when having a struct with missing wind speed in knots", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInKnots = NewInvalidFloat64("")
+				parsed.WindSpeedInKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid relative wind direction", func() {
 				Expect(parsed.GetRelativeWindDirection()).To(BeNumerically("~", RelativeDirectionRadians, 0.00001))
@@ -116,8 +116,8 @@ var _ = Describe("VWR", func() {
 		})
 		Context("when having a struct with missing wind speed in meters per second and wind speed in knots", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInMetersPerSecond = NewInvalidFloat64("")
-				parsed.WindSpeedInKnots = NewInvalidFloat64("")
+				parsed.WindSpeedInMetersPerSecond = NewInvalidSpeed("")
+				parsed.WindSpeedInKnots = NewInvalidSpeed("")
 			})
 			It("returns a valid relative wind direction", func() {
 				Expect(parsed.GetRelativeWindDirection()).To(BeNumerically("~", RelativeDirectionRadians, 0.00001))
@@ -128,8 +128,8 @@ var _ = Describe("VWR", func() {
 		})
 		Context("when having a struct with missing wind speed in meters per second and wind speed in kilometer per hour", func() {
 			JustBeforeEach(func() {
-				parsed.WindSpeedInMetersPerSecond = NewInvalidFloat64("")
-				parsed.WindSpeedInKilometersPerHour = NewInvalidFloat64("")
+				parsed.WindSpeedInMetersPerSecond = NewInvalidSpeed("")
+				parsed.WindSpeedInKilometersPerHour = NewInvalidSpeed("")
 			})
 			It("returns a valid relative wind direction", func() {
 				Expect(parsed.GetRelativeWindDirection()).To(BeNumerically("~", RelativeDirectionRadians, 0.00001))
@@ -24,3 +24,12 @@ func newWPL(s BaseSentence) (WPL, error) {
 		Ident:        p.String(4, "ident of nth waypoint"),
 	}, p.Err()
 }
+
+// Encode serializes the WPL sentence into NMEA 0183 text
+func (s WPL) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Latitude(s.Latitude)
+	e.Longitude(s.Longitude)
+	e.StringField(s.Ident)
+	return e.Encode()
+}
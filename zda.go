@@ -18,8 +18,8 @@ type ZDA struct {
 	Day           Int64
 	Month         Int64
 	Year          Int64
-	OffsetHours   Int64 // Local time zone offset from GMT, hours
-	OffsetMinutes Int64 // Local time zone offset from GMT, minutes
+	OffsetHours   Int64 // Local zone hours, added to the local time to get UTC, negative east of Greenwich
+	OffsetMinutes Int64 // Local zone minutes, same sign as the hours
 }
 
 // newZDA constructor
@@ -39,29 +39,82 @@ func newZDA(s BaseSentence) (ZDA, error) {
 
 // GetDateTime retrieves the date and time in RFC3339Nano format
 func (s ZDA) GetDateTime() (string, error) {
-	if !s.Time.Valid {
-		return "", fmt.Errorf("value is unavailable")
+	t, err := s.GetTimestamp()
+	if err != nil {
+		return "", err
 	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// GetTimestamp retrieves the date and time in UTC from the sentence
+func (s ZDA) GetTimestamp() (time.Time, error) {
 	day, err := s.Day.GetValue()
 	if err != nil {
-		return "", fmt.Errorf("value is unavailable")
+		return time.Time{}, fmt.Errorf("value is unavailable")
 	}
 	month, err := s.Month.GetValue()
 	if err != nil {
-		return "", fmt.Errorf("value is unavailable")
+		return time.Time{}, fmt.Errorf("value is unavailable")
 	}
 	year, err := s.Year.GetValue()
 	if err != nil {
-		return "", fmt.Errorf("value is unavailable")
+		return time.Time{}, fmt.Errorf("value is unavailable")
+	}
+	return s.dateConfig.Time(NewDate(int(year), int(month), int(day)), s.Time)
+}
+
+// GetLocation retrieves the local time zone from the sentence. NMEA 0183 defines the local zone as
+// the value that is added to the local time to get UTC, so it is negative east of Greenwich, e.g.
+// -02,00 is UTC+2. The minutes have the same sign as the hours.
+func (s ZDA) GetLocation() (*time.Location, error) {
+	hours, err := s.OffsetHours.GetValue()
+	if err != nil {
+		return nil, fmt.Errorf("value is unavailable")
+	}
+	minutes, err := s.OffsetMinutes.GetValue()
+	if err != nil {
+		return nil, fmt.Errorf("value is unavailable")
+	}
+	zone := int(hours)*3600 + int(minutes)*60
+	if hours < 0 && minutes > 0 {
+		zone = int(hours)*3600 - int(minutes)*60
+	}
+	offset := -zone
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone("", offset), nil
+}
+
+// GetLocalTime retrieves the date and time in the local time zone from the sentence
+func (s ZDA) GetLocalTime() (time.Time, error) {
+	t, err := s.GetTimestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := s.GetLocation()
+	if err != nil {
+		return time.Time{}, err
 	}
-	return time.Date(
-		int(year),
-		time.Month(month),
-		int(day),
-		s.Time.Hour,
-		s.Time.Minute,
-		s.Time.Second,
-		s.Time.Millisecond*1000000,
-		time.UTC,
-	).UTC().Format(time.RFC3339Nano), nil
+	return t.In(location), nil
+}
+
+// GetTimeOfDay retrieves the UTC time since midnight from the sentence
+func (s ZDA) GetTimeOfDay() (time.Duration, error) {
+	if s.Time.Valid {
+		return s.Time.Duration(), nil
+	}
+	return 0, fmt.Errorf("value is unavailable")
+}
+
+// Encode serializes the ZDA sentence into NMEA 0183 text
+func (s ZDA) Encode() (string, error) {
+	e := NewEncoder(s.BaseSentence)
+	e.Time(s.Time)
+	e.FixedInt64(s.Day, 2)
+	e.FixedInt64(s.Month, 2)
+	e.FixedInt64(s.Year, 4)
+	e.FixedInt64(s.OffsetHours, 2)
+	e.FixedInt64(s.OffsetMinutes, 2)
+	return e.Encode()
 }
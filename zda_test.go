@@ -1,6 +1,8 @@
 package nmea_test
 
 import (
+	"time"
+
 	. "github.com/munnik/go-nmea"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -89,6 +91,48 @@ var _ = Describe("ZDA", func() {
 		Context("when having a complete struct", func() {
 			It("returns a valid date and time", func() {
 				Expect(parsed.GetDateTime()).To(Equal("2021-04-16T20:05:45.315Z"))
+				Expect(parsed.GetTimestamp()).To(Equal(time.Date(2021, 4, 16, 20, 5, 45, 315000000, time.UTC)))
+			})
+			It("returns a valid time of day", func() {
+				Expect(parsed.GetTimeOfDay()).To(Equal(20*time.Hour + 5*time.Minute + 45*time.Second + 315*time.Millisecond))
+			})
+		})
+		Context("when having a local time zone", func() {
+			JustBeforeEach(func() {
+				parsed.OffsetHours = NewInt64(-3)
+				parsed.OffsetMinutes = NewInt64(30)
+			})
+			It("returns the local time zone", func() {
+				location, err := parsed.GetLocation()
+				Expect(err).ToNot(HaveOccurred())
+				_, offset := time.Date(2021, 4, 16, 0, 0, 0, 0, location).Zone()
+				Expect(offset).To(Equal(3*3600 + 30*60))
+			})
+			It("returns the local time", func() {
+				local, err := parsed.GetLocalTime()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(local.Hour()).To(Equal(23))
+				Expect(local.Minute()).To(Equal(35))
+				Expect(local.Equal(time.Date(2021, 4, 16, 20, 5, 45, 315000000, time.UTC))).To(BeTrue())
+			})
+		})
+		Context("when having a local time zone west of Greenwich", func() {
+			JustBeforeEach(func() {
+				parsed.OffsetHours = NewInt64(5)
+				parsed.OffsetMinutes = NewInt64(0)
+			})
+			It("returns the local time", func() {
+				local, err := parsed.GetLocalTime()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(local.Hour()).To(Equal(15))
+				_, offset := local.Zone()
+				Expect(offset).To(Equal(-5 * 3600))
+			})
+		})
+		Context("when missing the local time zone", func() {
+			It("returns an error", func() {
+				_, err := parsed.GetLocation()
+				Expect(err).To(HaveOccurred())
 			})
 		})
 		Context("when missing time", func() {